@@ -8,12 +8,16 @@ import (
 	"lakelens/internal/auth"
 	configs "lakelens/internal/config"
 	"lakelens/internal/consts"
+	deltahdlr "lakelens/internal/handlers/delta"
+	hudihdlr "lakelens/internal/handlers/hudi"
 	iceberghdlr "lakelens/internal/handlers/iceberg"
 	managerhdlr "lakelens/internal/handlers/manager"
 	publichdlr "lakelens/internal/handlers/public"
 	"lakelens/internal/middlewares"
 	tracelog "lakelens/internal/middlewares/traceLog"
 	"lakelens/internal/notifications/mailer"
+	deltasrvc "lakelens/internal/services/delta"
+	hudisrvc "lakelens/internal/services/hudi"
 	icebergserv "lakelens/internal/services/iceberg"
 	managersrvc "lakelens/internal/services/manager"
 	publicsrvc "lakelens/internal/services/public"
@@ -159,6 +163,20 @@ func initRoutes(router *gin.Engine, ds *db.DataStore) error {
 	icebergHandler.RegisterRoutes(icebergGrp)
 	// >
 
+	// < Delta
+	deltaService := deltasrvc.NewDeltaService(queries, redis, pool, stashService)
+	deltaHandler := deltahdlr.NewDeltaHandler(deltaService)
+	deltaGrp := lensGrp.Group("/" + consts.DeltaTable)
+	deltaHandler.RegisterRoutes(deltaGrp)
+	// >
+
+	// < Hudi
+	hudiService := hudisrvc.NewHudiService(queries, redis, pool, stashService)
+	hudiHandler := hudihdlr.NewHudiHandler(hudiService)
+	hudiGrp := lensGrp.Group("/" + consts.HudiTable)
+	hudiHandler.RegisterRoutes(hudiGrp)
+	// >
+
 	// < Manager
 	managerService := managersrvc.NewManagerService(queries, redis, pool, stashService, icebergService)
 	managerHandler := managerhdlr.NewManagerHandler(managerService)
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"errors"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/gin-gonic/gin"
+)
+
+// ListContainers lists all containers for a given client.
+func ListContainers(ctx *gin.Context, client *azblob.Client) ([]*dto.BucketData, error) {
+
+	containers := make([]*dto.BucketData, 0)
+
+	pager := client.NewListContainersPager(nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.ContainerItems {
+			if item.Name == nil {
+				continue
+			}
+			data := &dto.BucketData{
+				Name:        *item.Name,
+				StorageType: consts.Azure,
+			}
+			if item.Properties != nil {
+				data.CreationDate = item.Properties.LastModified
+			}
+			containers = append(containers, data)
+		}
+	}
+
+	return containers, nil
+}
+
+// GetContainer determines if container exists and if access is allowed, returns some metadata too.
+func GetContainer(ctx *gin.Context, client *azblob.Client, containerName string) (*dto.BucketData, *errs.Errorf) {
+
+	props, err := client.ServiceClient().NewContainerClient(containerName).GetProperties(ctx, nil)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			switch respErr.StatusCode {
+			case http.StatusNotFound:
+				return nil, &errs.Errorf{
+					Type:      errs.ErrNotFound,
+					Message:   "Container not found : " + containerName,
+					ReturnRaw: true,
+				}
+			case http.StatusForbidden, http.StatusUnauthorized:
+				return nil, &errs.Errorf{
+					Type:      errs.ErrForbidden,
+					Message:   "Container access is forbidden : " + containerName + " : " + respErr.ErrorCode,
+					ReturnRaw: true,
+				}
+			}
+		}
+		return nil, &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to get container properties : " + err.Error(),
+		}
+	}
+
+	return &dto.BucketData{
+		Name:         containerName,
+		StorageType:  consts.Azure,
+		CreationDate: props.LastModified,
+	}, nil
+}
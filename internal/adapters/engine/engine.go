@@ -0,0 +1,271 @@
+package engine
+
+import (
+	"context"
+	"hash/fnv"
+	"lakelens/internal/adapters/engine/fetcher"
+	"lakelens/internal/adapters/objstore"
+	"lakelens/internal/adapters/pipeline"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ScrapeLoc handles the metadata extraction of the given location.
+//
+// The store can be of any provider (AWS S3, MinIO, Azure, etc), data is the already known location data.
+// If data has a prefix, the location is confined to it.
+// Every table found in the location is extracted, if there are none, a few parquet files are read instead.
+// The requests count against the limiter, shared by every location of the lake scanned at once.
+func ScrapeLoc(ctx context.Context, store objstore.ObjectStore, data *dto.BucketData, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf) {
+
+	newBucket := new(dto.NewBucket)
+	newBucket.Data = *data
+	newBucket.Data.StorageType = store.Provider()
+
+	// every request of this location, from detection to the pipelines, shares the provider limit.
+	store = objstore.WithLimit(store, limiter)
+
+	// the downloads of this scan go to their own directory, removed once it is done.
+	store, cleanup, errf := fetcher.WithScanDir(store)
+	if errf != nil {
+		return newBucket, errf
+	}
+	defer cleanup()
+
+	errf, defaultTo := DetermineTableTypeBFS(ctx, store, newBucket)
+	if errf != nil {
+		if defaultTo {
+			newBucket.Errors = append(newBucket.Errors, errf)
+		} else {
+			return newBucket, errf
+		}
+	}
+
+	newBucket.Data.TableCount = len(newBucket.Tables)
+
+	if len(newBucket.Tables) == 0 {
+		newBucket.Data.TableType = consts.ParquetFile
+		newBucket.Parquet.Present = true
+		_, errf := pipeline.HandleParquet(ctx, store, newBucket)
+		if errf != nil {
+			return newBucket, errf
+		}
+
+		return newBucket, nil
+	}
+
+	newBucket.Data.TableType = newBucket.Tables[0].TableType
+	for _, table := range newBucket.Tables {
+		if table.TableType != newBucket.Data.TableType {
+			newBucket.Data.TableType = consts.MixedTables
+			break
+		}
+	}
+
+	// the tables are extracted concurrently, bounded by the config.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var fatal *errs.Errorf
+	sem := make(chan struct{}, max(configs.Extras.TablesConcurrency, 1))
+
+	for _, table := range newBucket.Tables {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(table *dto.Table) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errf := scrapeTable(ctx, store, table, opts)
+			if errf != nil {
+				if errf.ReturnRaw {
+					table.Errors = append(table.Errors, errf)
+					return
+				}
+				mu.Lock()
+				if fatal == nil {
+					fatal = errf
+				}
+				mu.Unlock()
+			}
+		}(table)
+	}
+	wg.Wait()
+
+	if fatal != nil {
+		return newBucket, fatal
+	}
+
+	return newBucket, nil
+}
+
+// scrapeTable runs the format pipeline for a single detected table.
+func scrapeTable(ctx context.Context, store objstore.ObjectStore, table *dto.Table, opts dto.ScanOptions) *errs.Errorf {
+
+	switch table.TableType {
+	case consts.IcebergTable:
+		_, errf := pipeline.HandleIceberg(ctx, store, table, opts)
+		return errf
+	case consts.DeltaTable:
+		_, errf := pipeline.HandleDelta(ctx, store, table)
+		return errf
+	case consts.HudiTable:
+		_, errf := pipeline.HandleHudi(ctx, store, table)
+		return errf
+	}
+
+	return nil
+}
+
+// detectTable checks if the folder {parent} is the root of a table, given all its sub folders.
+//
+// Returns nil if it is not.
+func detectTable(parent string, children []string) *dto.Table {
+
+	var metaFolder, dataFolder, deltaFolder, hudiFolder bool
+	for _, child := range children {
+		switch "/" + strings.TrimPrefix(child, parent) {
+		case consts.IcebergMetaFolder:
+			metaFolder = true
+		case consts.IcebergDataFolder:
+			dataFolder = true
+		case consts.DeltaLogFolder:
+			deltaFolder = true
+		case consts.HudiMetaFolder:
+			hudiFolder = true
+		}
+	}
+
+	table := &dto.Table{
+		ID:  tableID(parent),
+		URI: parent,
+	}
+
+	switch {
+	case metaFolder && dataFolder:
+		table.TableType = consts.IcebergTable
+		table.Iceberg.Present = true
+		table.Iceberg.URI = parent + strings.TrimPrefix(consts.IcebergMetaFolder, "/")
+	case deltaFolder:
+		table.TableType = consts.DeltaTable
+		table.Delta.Present = true
+		table.Delta.URI = parent + strings.TrimPrefix(consts.DeltaLogFolder, "/")
+	case hudiFolder:
+		table.TableType = consts.HudiTable
+		table.Hudi.Present = true
+		table.Hudi.URI = parent + strings.TrimPrefix(consts.HudiMetaFolder, "/")
+	default:
+		return nil
+	}
+
+	return table
+}
+
+// tableID returns the stable id of a table from its root uri, so it survives rescans.
+func tableID(uri string) string {
+	h := fnv.New64a()
+	h.Write([]byte(uri))
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// DetermineTableType determines/detects the tables in a given bucket by recursively listing nested folders.
+//
+// This is the DFS based approach.
+func DetermineTableType(ctx context.Context, store objstore.ObjectStore, newBucket *dto.NewBucket, prefix string, depth int) *errs.Errorf {
+
+	if depth <= 0 {
+		return nil
+	}
+
+	rootFolders, err := objstore.ListAll(ctx, store, prefix, "/")
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Unable to list objects (folders) : " + err.Error(),
+		}
+	}
+
+	if table := detectTable(prefix, rootFolders.Prefixes); table != nil {
+		newBucket.Tables = append(newBucket.Tables, table)
+		return nil
+	}
+
+	for _, pre := range rootFolders.Prefixes {
+		if len(newBucket.Tables) >= configs.Extras.TablesPerLocLimit {
+			break
+		}
+		errf := DetermineTableType(ctx, store, newBucket, pre, depth-1)
+		if errf != nil {
+			return errf
+		}
+	}
+
+	return nil
+}
+
+// DetermineTableTypeBFS detects every table in a given bucket.
+//
+// This is the BFS based approach. This should perform better for most cases.
+// All folders of a level are listed concurrently, bounded by the provider concurrency.
+// A folder holding a table is never descended into, so tables inside tables are not looked for.
+// The scan starts at the location prefix, nothing outside of it is listed.
+// Returns true if no table was found, to let the caller default to extracting parquet files.
+func DetermineTableTypeBFS(ctx context.Context, store objstore.ObjectStore, newBucket *dto.NewBucket) (*errs.Errorf, bool) {
+
+	queue := []string{newBucket.Data.Prefix}
+	maxDepth := configs.Extras.DetermineTableTypeMaxDepth
+	workers := configs.Extras.ProviderConcurrency(store.Provider())
+	subQueue := []string{}
+
+	for maxDepth > 0 && len(queue) > 0 {
+		subQueue = subQueue[:0]
+
+		levelFolders, err := objstore.ListMany(ctx, store, queue, "/", workers)
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrServiceUnavailable,
+				Message: "Unable to list objects (folders) : " + err.Error(),
+			}, false
+		}
+
+		// results keep the queue order, so the cataloged tables are the same on every scan.
+		for i, prefix := range queue {
+
+			if len(newBucket.Tables) >= configs.Extras.TablesPerLocLimit {
+				newBucket.Errors = append(newBucket.Errors, &errs.Errorf{
+					Type:      errs.ErrOutOfRange,
+					Message:   "Maximum tables per location reached, the remaining folders were not scanned.",
+					ReturnRaw: true,
+				})
+				subQueue = subQueue[:0]
+				break
+			}
+
+			if table := detectTable(prefix, levelFolders[i].Prefixes); table != nil {
+				newBucket.Tables = append(newBucket.Tables, table)
+				continue
+			}
+
+			subQueue = append(subQueue, levelFolders[i].Prefixes...)
+		}
+
+		queue = slices.Clone(subQueue)
+		maxDepth--
+	}
+
+	if len(newBucket.Tables) > 0 {
+		return nil, false
+	}
+
+	return &errs.Errorf{
+		Type:      errs.ErrNotFound,
+		Message:   "Maximum allowed depth reached but no table type found. Defaulting to extract few .parquet files if found.",
+		ReturnRaw: true,
+	}, true
+}
@@ -0,0 +1,270 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"lakelens/internal/adapters/objstore"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// scanStore saves the downloads of the wrapped store in its own directory, see WithScanDir.
+type scanStore struct {
+	objstore.ObjectStore
+	dir string
+}
+
+// Unwrap returns the underlying store.
+func (s *scanStore) Unwrap() objstore.ObjectStore {
+	return s.ObjectStore
+}
+
+// WithScanDir makes a fresh download directory for a single scan of the store's location and
+// wraps the store so that everything fetched through it is saved there.
+//
+// Same named buckets of different lakes/endpoints (or two scans of the same one) never share files.
+// The returned cleanup removes the directory, call it once the scan is done.
+func WithScanDir(store objstore.ObjectStore) (objstore.ObjectStore, func(), *errs.Errorf) {
+
+	baseDir := providerDir(store.Provider())
+	err := os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to make download directory : " + err.Error(),
+		}
+	}
+
+	dir, err := os.MkdirTemp(baseDir, store.Name()+"-*")
+	if err != nil {
+		return nil, nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to make scan download directory : " + err.Error(),
+		}
+	}
+
+	return &scanStore{ObjectStore: store, dir: dir}, func() { os.RemoveAll(dir) }, nil
+}
+
+// providerDir returns the directory the downloads of the given provider are saved under.
+func providerDir(provider string) string {
+
+	switch provider {
+	case consts.Azure:
+		return configs.Paths.DownSavePaths.AzureDownPath
+	case consts.GCS:
+		return configs.Paths.DownSavePaths.GCSDownPath
+	case consts.Local:
+		return configs.Paths.DownSavePaths.LocalDownPath
+	default:
+		return configs.Paths.DownSavePaths.S3DownPath
+	}
+}
+
+// downDir returns the directory the objects of the given store are saved in,
+// the scan directory if the store (or any store it wraps) came from WithScanDir.
+func downDir(store objstore.ObjectStore) string {
+
+	for inner := store; ; {
+		if scan, ok := inner.(*scanStore); ok {
+			return scan.dir
+		}
+		w, ok := inner.(interface{ Unwrap() objstore.ObjectStore })
+		if !ok {
+			break
+		}
+		inner = w.Unwrap()
+	}
+
+	return filepath.Join(providerDir(store.Provider()), store.Name())
+}
+
+// DownloadParquet downloads all parquet files given in leafFilePaths concurrently.
+//
+// By default fetches the last 48 KB.
+func DownloadParquet(ctx context.Context, store objstore.ObjectStore, leafFilePaths []string) ([]string, error) {
+
+	dwnldPaths := make([]string, 0)
+
+	// TODO: replace this to be dynamic
+	tailSize := int64(48000)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for i, path := range leafFilePaths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+
+			filePath := ""
+			defer func() {
+				mu.Lock()
+				dwnldPaths = append(dwnldPaths, filePath)
+				mu.Unlock()
+			}()
+
+			body, err := store.GetRange(ctx, path, -tailSize, 0)
+			if err != nil {
+				return
+			}
+			defer body.Close()
+
+			outfile, err := os.Create(fmt.Sprintf("%d", i))
+			if err != nil {
+				return
+			}
+			defer outfile.Close()
+
+			_, err = outfile.ReadFrom(body)
+			if err != nil {
+				return
+			}
+
+			filePath = outfile.Name()
+		}(i, path)
+	}
+	wg.Wait()
+	return dwnldPaths, nil
+}
+
+// DownloadSingleParquet downloads  a parquet file from given URI.
+//
+// It first fetches last 8 bytes to determine the footer length and
+// then downloads the footer accordingly.
+// It increases latency and API calls but zeros the chances of incomplete footer fetching.
+// This shouldn't be an issue as a limit is already in place to limit the number of downloads per request.
+func DownloadSingleParquet(ctx context.Context, store objstore.ObjectStore, uri string) (string, *errs.Errorf) {
+
+	objFooter, err := store.GetRange(ctx, uri, -8, 0)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to get parquet file :  " + err.Error(),
+		}
+	}
+
+	objBody := make([]byte, 8)
+	_, err = io.ReadFull(objFooter, objBody)
+	objFooter.Close()
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Failed to read parquet file footer length : " + err.Error(),
+		}
+	}
+	footerLen := int64(binary.LittleEndian.Uint32(objBody[:4]))
+
+	completeObj, err := store.GetRange(ctx, uri, -(footerLen + 8), 0)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to get parquet file :  " + err.Error(),
+		}
+	}
+	defer completeObj.Close()
+
+	dirPath := downDir(store)
+	err = os.MkdirAll(dirPath, 0755)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to create parquet download directory : " + err.Error(),
+		}
+	}
+
+	pathSplits := strings.Split(uri, "/")
+	filePath := filepath.Join(dirPath, pathSplits[len(pathSplits)-1])
+	outFile, err := os.Create(filePath)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to create parquet file : " + err.Error(),
+		}
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, completeObj)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to copy/write to outFile : " + err.Error(),
+		}
+	}
+
+	return filePath, nil
+}
+
+// FetchNdSave downloads the given file at {key} in the store and saves it at {saveDir/storeName/key}.
+// If key is empty, it is resolved from the full object uri {objFullPath}.
+// The key path is kept, so same named files of different tables (like hoodie.properties) never collide.
+func FetchNdSave(ctx context.Context, store objstore.ObjectStore, key, objFullPath string) (string, *errs.Errorf) {
+
+	if key == "" && objFullPath == "" {
+		return "", &errs.Errorf{
+			Type:    errs.ErrBadForm,
+			Message: "Object key and full path cannot be empty.",
+		}
+	}
+
+	if key == "" && objFullPath != "" {
+		var found bool
+		key, found = objstore.KeyFromURI(store, objFullPath)
+		if !found {
+			return "", &errs.Errorf{
+				Type:    errs.ErrBadForm,
+				Message: "The full object path does not belong to the location : " + objFullPath,
+			}
+		}
+	}
+
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to get object : " + err.Error(),
+		}
+	}
+	defer obj.Close()
+
+	relPath := filepath.FromSlash(key)
+	if !filepath.IsLocal(relPath) {
+		pathSplits := strings.Split(key, "/")
+		relPath = pathSplits[len(pathSplits)-1]
+	}
+	filePath := filepath.Join(downDir(store), relPath)
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to make directory : " + err.Error(),
+		}
+	}
+
+	outFile, err := os.Create(filePath)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to create file : " + err.Error(),
+		}
+	}
+	defer outFile.Close()
+
+	_, err = io.Copy(outFile, obj)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to copy/write to outFile : " + err.Error(),
+		}
+	}
+
+	return filePath, nil
+}
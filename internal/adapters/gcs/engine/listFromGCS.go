@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"errors"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// ListBuckets lists all buckets of the project for a given client.
+func ListBuckets(ctx *gin.Context, client *storage.Client, projectID string) ([]*dto.BucketData, error) {
+
+	buckets := make([]*dto.BucketData, 0)
+
+	it := client.Buckets(ctx, projectID)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		buckets = append(buckets, bucketToData(attrs))
+	}
+
+	return buckets, nil
+}
+
+// GetBucket determines if bucket exists and if access is allowed, returns some metadata too.
+func GetBucket(ctx *gin.Context, client *storage.Client, bucketName string) (*dto.BucketData, *errs.Errorf) {
+
+	attrs, err := client.Bucket(bucketName).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrNotFound,
+				Message:   "Bucket not found : " + bucketName,
+				ReturnRaw: true,
+			}
+		}
+		var gerr *googleapi.Error
+		if errors.As(err, &gerr) && (gerr.Code == http.StatusForbidden || gerr.Code == http.StatusUnauthorized) {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrForbidden,
+				Message:   "Bucket access is forbidden : " + bucketName + " : " + gerr.Message,
+				ReturnRaw: true,
+			}
+		}
+		return nil, &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to get bucket attributes : " + err.Error(),
+		}
+	}
+
+	return bucketToData(attrs), nil
+}
+
+func bucketToData(attrs *storage.BucketAttrs) *dto.BucketData {
+
+	data := &dto.BucketData{
+		Name:        attrs.Name,
+		StorageType: consts.GCS,
+	}
+	if attrs.Location != "" {
+		data.Region = &attrs.Location
+	}
+	if !attrs.Created.IsZero() {
+		data.CreationDate = &attrs.Created
+	}
+
+	return data
+}
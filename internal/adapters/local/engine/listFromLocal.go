@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"errors"
+	"io/fs"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ListDirs lists all sub directories (locations) of the given lake root.
+func ListDirs(root string) ([]*dto.BucketData, error) {
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]*dto.BucketData, 0)
+	for _, entry := range entries {
+		// hidden folders are never treated as locations.
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		modTime := info.ModTime()
+		dirs = append(dirs, &dto.BucketData{
+			Name:         entry.Name(),
+			StorageType:  consts.Local,
+			CreationDate: &modTime,
+		})
+	}
+
+	return dirs, nil
+}
+
+// GetDir determines if the location directory exists under root and is readable, returns some metadata too.
+func GetDir(root, dirName string) (*dto.BucketData, *errs.Errorf) {
+
+	if !filepath.IsLocal(dirName) || strings.ContainsAny(dirName, `/\`) {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "Location must be a direct sub directory of the lake root : " + dirName,
+			ReturnRaw: true,
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(root, dirName))
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil, &errs.Errorf{
+				Type:      errs.ErrNotFound,
+				Message:   "Directory not found : " + dirName,
+				ReturnRaw: true,
+			}
+		case errors.Is(err, fs.ErrPermission):
+			return nil, &errs.Errorf{
+				Type:      errs.ErrForbidden,
+				Message:   "Directory access is forbidden : " + dirName,
+				ReturnRaw: true,
+			}
+		}
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to stat directory : " + err.Error(),
+		}
+	}
+	if !info.IsDir() {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "Location is not a directory : " + dirName,
+			ReturnRaw: true,
+		}
+	}
+
+	modTime := info.ModTime()
+	return &dto.BucketData{
+		Name:         dirName,
+		StorageType:  consts.Local,
+		CreationDate: &modTime,
+	}, nil
+}
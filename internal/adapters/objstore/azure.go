@@ -0,0 +1,181 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"lakelens/internal/consts"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+)
+
+// AzureStore is the ObjectStore for Azure Blob Storage / ADLS Gen2 containers.
+type AzureStore struct {
+	client    *container.Client
+	container string
+}
+
+func NewAzureStore(client *azblob.Client, containerName string) *AzureStore {
+	return &AzureStore{
+		client:    client.ServiceClient().NewContainerClient(containerName),
+		container: containerName,
+	}
+}
+
+func (s *AzureStore) Provider() string {
+	return consts.Azure
+}
+
+func (s *AzureStore) Name() string {
+	return s.container
+}
+
+func (s *AzureStore) List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error) {
+
+	var pre, marker *string
+	if prefix != "" {
+		pre = &prefix
+	}
+	if token != "" {
+		marker = &token
+	}
+
+	result := new(ListResult)
+
+	if delimiter == "" {
+		pager := s.client.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+			Prefix: pre,
+			Marker: marker,
+		})
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if page.Segment != nil {
+			for _, item := range page.Segment.BlobItems {
+				result.Objects = append(result.Objects, blobToObject(item))
+			}
+		}
+		if page.NextMarker != nil {
+			result.NextToken = *page.NextMarker
+		}
+		return result, nil
+	}
+
+	pager := s.client.NewListBlobsHierarchyPager(delimiter, &container.ListBlobsHierarchyOptions{
+		Prefix: pre,
+		Marker: marker,
+	})
+	page, err := pager.NextPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if page.Segment != nil {
+		for _, item := range page.Segment.BlobItems {
+			result.Objects = append(result.Objects, blobToObject(item))
+		}
+		for _, p := range page.Segment.BlobPrefixes {
+			if p.Name != nil {
+				result.Prefixes = append(result.Prefixes, *p.Name)
+			}
+		}
+	}
+	if page.NextMarker != nil {
+		result.NextToken = *page.NextMarker
+	}
+
+	return result, nil
+}
+
+func (s *AzureStore) Head(ctx context.Context, key string) (*Object, error) {
+
+	props, err := s.client.NewBlobClient(key).GetProperties(ctx, nil)
+	if err != nil {
+		return nil, azureNotExist(err, key)
+	}
+
+	obj := &Object{
+		Key: key,
+	}
+	if props.ContentLength != nil {
+		obj.Size = *props.ContentLength
+	}
+	if props.LastModified != nil {
+		obj.LastModified = *props.LastModified
+	}
+
+	return obj, nil
+}
+
+func (s *AzureStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+
+	// azure does not support suffix ranges, so the size is needed first.
+	if offset < 0 {
+		obj, err := s.Head(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		length = min(-offset, obj.Size)
+		offset = obj.Size - length
+	}
+	// a zero count reads till the end.
+	length = max(length, 0)
+
+	resp, err := s.client.NewBlobClient(key).DownloadStream(ctx, &blob.DownloadStreamOptions{
+		Range: blob.HTTPRange{
+			Offset: offset,
+			Count:  length,
+		},
+	})
+	if err != nil {
+		return nil, azureNotExist(err, key)
+	}
+
+	return resp.Body, nil
+}
+
+func (s *AzureStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	resp, err := s.client.NewBlobClient(key).DownloadStream(ctx, nil)
+	if err != nil {
+		return nil, azureNotExist(err, key)
+	}
+
+	return resp.Body, nil
+}
+
+// azureNotExist wraps err with ErrNotExist if it is a missing blob response.
+func azureNotExist(err error, key string) error {
+
+	var respErr *azcore.ResponseError
+	if bloberror.HasCode(err, bloberror.BlobNotFound) || (errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound) {
+		return fmt.Errorf("%w : %s : %v", ErrNotExist, key, err)
+	}
+
+	return err
+}
+
+func blobToObject(item *container.BlobItem) Object {
+
+	obj := Object{}
+	if item.Name != nil {
+		obj.Key = *item.Name
+	}
+	if item.Properties != nil {
+		if item.Properties.ContentLength != nil {
+			obj.Size = *item.Properties.ContentLength
+		}
+		if item.Properties.LastModified != nil {
+			obj.LastModified = *item.Properties.LastModified
+		}
+	}
+
+	return obj
+}
@@ -0,0 +1,119 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"lakelens/internal/consts"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// GCSStore is the ObjectStore for Google Cloud Storage buckets.
+type GCSStore struct {
+	client *storage.BucketHandle
+	bucket string
+}
+
+func NewGCSStore(client *storage.Client, bucketName string) *GCSStore {
+	return &GCSStore{
+		client: client.Bucket(bucketName),
+		bucket: bucketName,
+	}
+}
+
+func (s *GCSStore) Provider() string {
+	return consts.GCS
+}
+
+func (s *GCSStore) Name() string {
+	return s.bucket
+}
+
+func (s *GCSStore) List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error) {
+
+	query := &storage.Query{
+		Prefix:    prefix,
+		Delimiter: delimiter,
+	}
+	err := query.SetAttrSelection([]string{"Name", "Size", "Updated", "Prefix"})
+	if err != nil {
+		return nil, err
+	}
+
+	// same as the others, a single page of 1000 keys
+	var attrs []*storage.ObjectAttrs
+	nextToken, err := iterator.NewPager(s.client.Objects(ctx, query), 1000, token).NextPage(&attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{
+		NextToken: nextToken,
+	}
+	for _, attr := range attrs {
+		// with a delimiter, the synthetic folder entries only have the prefix set
+		if attr.Prefix != "" {
+			result.Prefixes = append(result.Prefixes, attr.Prefix)
+			continue
+		}
+		result.Objects = append(result.Objects, Object{
+			Key:          attr.Name,
+			Size:         attr.Size,
+			LastModified: attr.Updated,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *GCSStore) Head(ctx context.Context, key string) (*Object, error) {
+
+	attrs, err := s.client.Object(key).Attrs(ctx)
+	if err != nil {
+		return nil, gcsNotExist(err, key)
+	}
+
+	return &Object{
+		Key:          key,
+		Size:         attrs.Size,
+		LastModified: attrs.Updated,
+	}, nil
+}
+
+func (s *GCSStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+
+	// gcs supports suffix ranges natively with a negative offset, length must be -1 then.
+	if offset < 0 || length <= 0 {
+		length = -1
+	}
+
+	reader, err := s.client.Object(key).NewRangeReader(ctx, offset, length)
+	if err != nil {
+		return nil, gcsNotExist(err, key)
+	}
+
+	return reader, nil
+}
+
+func (s *GCSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	reader, err := s.client.Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, gcsNotExist(err, key)
+	}
+
+	return reader, nil
+}
+
+// gcsNotExist wraps err with ErrNotExist if it is a missing object response.
+func gcsNotExist(err error, key string) error {
+
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("%w : %s : %v", ErrNotExist, key, err)
+	}
+
+	return err
+}
@@ -0,0 +1,89 @@
+package objstore
+
+import (
+	"context"
+	"io"
+)
+
+// Limiter bounds the number of requests in flight at once, across every store wrapped with it.
+// Share one between the stores of a lake to cap the requests to its provider, not to each location.
+type Limiter struct {
+	sem chan struct{}
+}
+
+// NewLimiter returns a limiter allowing at most n requests in flight at once.
+func NewLimiter(n int) *Limiter {
+	return &Limiter{
+		sem: make(chan struct{}, max(n, 1)),
+	}
+}
+
+// limitedStore bounds the number of requests in flight at once against the wrapped store.
+type limitedStore struct {
+	ObjectStore
+	limiter *Limiter
+}
+
+// WithLimit wraps store so that its requests count against the limiter, along with the requests of every
+// other store wrapped with the same limiter.
+//
+// Only the request itself is bounded, reading a returned body is not.
+func WithLimit(store ObjectStore, limiter *Limiter) ObjectStore {
+	if _, ok := store.(*limitedStore); ok {
+		return store
+	}
+	return &limitedStore{
+		ObjectStore: store,
+		limiter:     limiter,
+	}
+}
+
+// Unwrap returns the underlying store.
+func (s *limitedStore) Unwrap() ObjectStore {
+	return s.ObjectStore
+}
+
+func (s *limitedStore) acquire(ctx context.Context) error {
+	select {
+	case s.limiter.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *limitedStore) release() {
+	<-s.limiter.sem
+}
+
+func (s *limitedStore) List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.ObjectStore.List(ctx, prefix, delimiter, token)
+}
+
+func (s *limitedStore) Head(ctx context.Context, key string) (*Object, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.ObjectStore.Head(ctx, key)
+}
+
+func (s *limitedStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.ObjectStore.GetRange(ctx, key, offset, length)
+}
+
+func (s *limitedStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.ObjectStore.Get(ctx, key)
+}
@@ -0,0 +1,248 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"lakelens/internal/consts"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// LocalStore is the ObjectStore for a directory on the local disk (or a mounted NFS share).
+// The directory is the location, keys are slash separated paths relative to it.
+type LocalStore struct {
+	dir  string // the absolute path of the location directory.
+	name string
+}
+
+// NewLocalStore binds the store to the directory {root}/{dirName}, root must be an absolute clean path.
+func NewLocalStore(root, dirName string) *LocalStore {
+	return &LocalStore{
+		dir:  filepath.Join(root, dirName),
+		name: dirName,
+	}
+}
+
+func (s *LocalStore) Provider() string {
+	return consts.Local
+}
+
+func (s *LocalStore) Name() string {
+	return s.name
+}
+
+// Dir returns the absolute path of the location directory.
+func (s *LocalStore) Dir() string {
+	return s.dir
+}
+
+// List walks the directory under prefix. Unlike the cloud stores, everything is returned in a single page.
+func (s *LocalStore) List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error) {
+
+	if token != "" {
+		return nil, fmt.Errorf("local store does not paginate, got a page token : %s", token)
+	}
+
+	if delimiter != "" && delimiter != "/" {
+		return nil, fmt.Errorf("local store only supports '/' as the delimiter, got : %s", delimiter)
+	}
+
+	// the prefix may end in a partial name, so start from the last complete folder.
+	baseKey := prefix[:strings.LastIndex(prefix, "/")+1]
+	baseDir, err := s.resolve(baseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ListResult)
+
+	if delimiter == "/" {
+		entries, err := os.ReadDir(baseDir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return result, nil
+			}
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			key := baseKey + entry.Name()
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+
+			if entry.IsDir() {
+				result.Prefixes = append(result.Prefixes, key+"/")
+				continue
+			}
+
+			obj, err := toObject(key, entry)
+			if err != nil {
+				return nil, err
+			}
+			result.Objects = append(result.Objects, obj)
+		}
+
+		return result, nil
+	}
+
+	err = filepath.WalkDir(baseDir, func(fPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, fPath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		obj, err := toObject(key, entry)
+		if err != nil {
+			return err
+		}
+		result.Objects = append(result.Objects, obj)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the same ordering as the cloud listings.
+	slices.SortFunc(result.Objects, func(a, b Object) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return result, nil
+}
+
+func (s *LocalStore) Head(ctx context.Context, key string) (*Object, error) {
+
+	fPath, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(fPath)
+	if err != nil {
+		return nil, localNotExist(err, key)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%w : %s : is a directory", ErrNotExist, key)
+	}
+
+	return &Object{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (s *LocalStore) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+
+	file, err := s.open(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	size := info.Size()
+
+	if offset < 0 {
+		length = min(-offset, size)
+		offset = size - length
+	} else if length <= 0 || offset+length > size {
+		length = max(size-offset, 0)
+	}
+
+	return &sectionReadCloser{
+		Reader: io.NewSectionReader(file, offset, length),
+		Closer: file,
+	}, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.open(key)
+}
+
+func (s *LocalStore) open(key string) (*os.File, error) {
+
+	fPath, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fPath)
+	if err != nil {
+		return nil, localNotExist(err, key)
+	}
+
+	return file, nil
+}
+
+// localNotExist wraps err with ErrNotExist if the file is missing.
+func localNotExist(err error, key string) error {
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w : %s : %v", ErrNotExist, key, err)
+	}
+
+	return err
+}
+
+// resolve returns the absolute path for the key, making sure it does not escape the location directory.
+func (s *LocalStore) resolve(key string) (string, error) {
+
+	key = strings.TrimSuffix(key, "/")
+	if key == "" {
+		return s.dir, nil
+	}
+
+	if !filepath.IsLocal(filepath.FromSlash(key)) || path.Clean(key) != key {
+		return "", fmt.Errorf("invalid key, it escapes the location : %s", key)
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func toObject(key string, entry fs.DirEntry) (Object, error) {
+
+	info, err := entry.Info()
+	if err != nil {
+		return Object{}, err
+	}
+
+	return Object{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+type sectionReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,183 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObjectStore is a storage agnostic view over a single location (s3/gcs bucket, azure container, etc).
+// The detection engine, the table format pipelines and the fetcher only ever talk to this,
+// so adding a provider never means touching format code.
+type ObjectStore interface {
+	// Provider returns the storage type of the store, one of consts.AWSS3, consts.Azure, consts.GCS, etc.
+	Provider() string
+	// Name returns the location (bucket/container) name the store is bound to.
+	Name() string
+
+	// List returns a single page of objects under prefix, starting at the page token (empty for the first page).
+	// If delimiter is not empty, the common prefixes (folders) are returned too.
+	// The returned NextToken is empty on the last page.
+	List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error)
+	// Head returns the metadata of a single object, ErrNotExist if there is none.
+	Head(ctx context.Context, key string) (*Object, error)
+	// GetRange returns length bytes of the object starting at offset, a length <= 0 reads till the end.
+	// A negative offset returns the last -offset bytes of the object and length is ignored.
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	// Get returns the entire object, ErrNotExist if there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ErrNotExist is returned (wrapped) by Head and Get when the object does not exist.
+var ErrNotExist = errors.New("object does not exist")
+
+// Object is a single listed object/blob.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+type ListResult struct {
+	Objects   []Object
+	Prefixes  []string // the common prefixes, always end with the delimiter.
+	NextToken string   // pass to List to get the next page, empty if this was the last one.
+}
+
+// ListAll pages through List till the end and returns everything under prefix.
+func ListAll(ctx context.Context, store ObjectStore, prefix, delimiter string) (*ListResult, error) {
+
+	all := new(ListResult)
+	token := ""
+
+	for {
+		page, err := store.List(ctx, prefix, delimiter, token)
+		if err != nil {
+			return nil, err
+		}
+
+		all.Objects = append(all.Objects, page.Objects...)
+		all.Prefixes = append(all.Prefixes, page.Prefixes...)
+
+		if page.NextToken == "" {
+			break
+		}
+		token = page.NextToken
+	}
+
+	return all, nil
+}
+
+// ListMany runs ListAll for every prefix, at most workers of them at once.
+//
+// The results are in the same order as prefixes. The first error cancels the remaining listings.
+func ListMany(ctx context.Context, store ObjectStore, prefixes []string, delimiter string, workers int) ([]*ListResult, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*ListResult, len(prefixes))
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	sem := make(chan struct{}, max(workers, 1))
+
+	for i, prefix := range prefixes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+
+		go func(i int, prefix string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := ListAll(ctx, store, prefix, delimiter)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			results[i] = res
+		}(i, prefix)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// unwrap returns the store under any wrappers, like the one from WithLimit.
+func unwrap(store ObjectStore) ObjectStore {
+	for {
+		w, ok := store.(interface{ Unwrap() ObjectStore })
+		if !ok {
+			return store
+		}
+		store = w.Unwrap()
+	}
+}
+
+// KeyFromURI resolves a full object uri (like the ones in iceberg metadata files) to a key in the given store.
+//
+// Supported forms:
+//   - s3://bucket/key, s3a://bucket/key, s3n://bucket/key
+//   - gs://bucket/key
+//   - abfs[s]://container@account.dfs.core.windows.net/key, wasb[s]://container@account.blob.core.windows.net/key
+//   - http[s]://host/container/key (azure blob urls and path-style S3-compatible urls)
+//   - file:///abs/dir/key and plain /abs/dir/key, only for local stores
+//
+// Returns false if the uri does not belong to the store's location.
+func KeyFromURI(store ObjectStore, uri string) (string, bool) {
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", false
+	}
+
+	var location, key string
+
+	if ls, ok := unwrap(store).(*LocalStore); ok {
+		if u.Scheme != "file" && u.Scheme != "" {
+			return "", false
+		}
+		key, found := strings.CutPrefix(filepath.ToSlash(filepath.Clean(u.Path)), filepath.ToSlash(ls.Dir())+"/")
+		return key, found
+	}
+
+	switch u.Scheme {
+	case "s3", "s3a", "s3n", "gs":
+		location = u.Host
+		key = u.Path
+	case "abfs", "abfss", "wasb", "wasbs":
+		location = u.User.Username()
+		key = u.Path
+	case "http", "https":
+		location, key, _ = strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	default:
+		return "", false
+	}
+
+	if location != store.Name() {
+		return "", false
+	}
+
+	return strings.TrimPrefix(key, "/"), true
+}
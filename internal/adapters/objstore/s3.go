@@ -0,0 +1,147 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Store is the ObjectStore for AWS S3 and all S3-compatible stores (MinIO, Ceph RGW, etc).
+type S3Store struct {
+	client   *s3.Client
+	bucket   string
+	provider string
+}
+
+func NewS3Store(client *s3.Client, bucket, provider string) *S3Store {
+	return &S3Store{
+		client:   client,
+		bucket:   bucket,
+		provider: provider,
+	}
+}
+
+func (s *S3Store) Provider() string {
+	return s.provider
+}
+
+func (s *S3Store) Name() string {
+	return s.bucket
+}
+
+func (s *S3Store) List(ctx context.Context, prefix, delimiter, token string) (*ListResult, error) {
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: &s.bucket,
+		Prefix: &prefix,
+	}
+	if delimiter != "" {
+		input.Delimiter = &delimiter
+	}
+	if token != "" {
+		input.ContinuationToken = &token
+	}
+
+	resp, err := s.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListResult{
+		Objects:  make([]Object, 0, len(resp.Contents)),
+		Prefixes: make([]string, 0, len(resp.CommonPrefixes)),
+	}
+	if aws.ToBool(resp.IsTruncated) {
+		result.NextToken = aws.ToString(resp.NextContinuationToken)
+	}
+
+	for _, obj := range resp.Contents {
+		if obj.Key == nil {
+			continue
+		}
+		result.Objects = append(result.Objects, Object{
+			Key:          *obj.Key,
+			Size:         aws.ToInt64(obj.Size),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+
+	for _, pre := range resp.CommonPrefixes {
+		if pre.Prefix != nil {
+			result.Prefixes = append(result.Prefixes, *pre.Prefix)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *S3Store) GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+
+	var rangeHeader string
+	switch {
+	case offset < 0:
+		rangeHeader = fmt.Sprintf("bytes=%d", offset)
+	case length <= 0:
+		rangeHeader = fmt.Sprintf("bytes=%d-", offset)
+	default:
+		rangeHeader = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	obj, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Range:  &rangeHeader,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Body, nil
+}
+
+func (s *S3Store) Head(ctx context.Context, key string) (*Object, error) {
+
+	obj, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, s3NotExist(err, key)
+	}
+
+	return &Object{
+		Key:          key,
+		Size:         aws.ToInt64(obj.ContentLength),
+		LastModified: aws.ToTime(obj.LastModified),
+	}, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+
+	obj, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, s3NotExist(err, key)
+	}
+
+	return obj.Body, nil
+}
+
+// s3NotExist wraps err with ErrNotExist if it is a missing key response.
+func s3NotExist(err error, key string) error {
+
+	var nsk *types.NoSuchKey
+	var nf *types.NotFound
+	if errors.As(err, &nsk) || errors.As(err, &nf) {
+		return fmt.Errorf("%w : %s : %v", ErrNotExist, key, err)
+	}
+
+	return err
+}
@@ -0,0 +1,543 @@
+package pipeline
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"lakelens/internal/adapters/engine/fetcher"
+	"lakelens/internal/adapters/objstore"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	deltaformats "lakelens/internal/dto/formats/delta"
+	deltautils "lakelens/internal/utils/delta"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// deltaCheckpointParts is a complete checkpoint found in the log folder.
+type deltaCheckpointParts struct {
+	version int64
+	keys    []string // the parts, in order.
+}
+
+// HandleDelta handles listing, downloading and replaying the log of the given Delta table into its latest snapshot.
+func HandleDelta(ctx context.Context, store objstore.ObjectStore, table *dto.Table) (bool, *errs.Errorf) {
+
+	// only the top level is listed, the sidecars, staged commits, etc are not needed.
+	resp, err := objstore.ListAll(ctx, store, table.Delta.URI, "/")
+	if err != nil {
+		return false, &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to list objects : " + err.Error(),
+		}
+	}
+
+	for _, obj := range resp.Objects {
+
+		key := obj.Key
+		if _, ok := deltautils.ParseVersion(key); ok {
+			table.Delta.LogFPaths = append(table.Delta.LogFPaths, key)
+		} else if _, _, _, ok := deltautils.ParseCheckpoint(key); ok {
+			table.Delta.CheckpointFPaths = append(table.Delta.CheckpointFPaths, key)
+		} else if path.Base(key) == consts.DeltaLastCheckpointFile {
+			table.Delta.LastCheckpointFPath = key
+		} else if strings.HasSuffix(key, ".crc") {
+			table.Delta.CRCFPaths = append(table.Delta.CRCFPaths, key)
+		}
+	}
+
+	errf := logOps(ctx, store, table)
+	if errf != nil {
+		return false, errf
+	}
+
+	dvOps(ctx, store, table)
+	cdcOps(ctx, store, table)
+
+	return false, nil
+}
+
+// logOps reconstructs the latest snapshot of the table, starting from the latest usable checkpoint and
+// replaying every commit after it. Older commits are read as well for the history, bounded by the config.
+func logOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	slices.Sort(table.Delta.LogFPaths)
+
+	commits := make(map[int64]string)
+	latest := int64(-1)
+	for _, key := range table.Delta.LogFPaths {
+		version, _ := deltautils.ParseVersion(key)
+		commits[version] = key
+		latest = max(latest, version)
+	}
+
+	checkpoints := completeCheckpoints(table.Delta.CheckpointFPaths)
+	if len(checkpoints) > 0 {
+		latest = max(latest, checkpoints[0].version)
+	}
+
+	if latest < 0 {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No delta commit or checkpoint files were found.",
+			ReturnRaw: true,
+		}
+	}
+
+	// the _last_checkpoint is only a hint, the listing is the truth. It picks between the
+	// checkpoints of the same version (single and multi-part), the newest version is always preferred.
+	if table.Delta.LastCheckpointFPath != "" {
+		hint, errf := readLastCheckpoint(ctx, store, table.Delta.LastCheckpointFPath)
+		if errf != nil {
+			table.Errors = append(table.Errors, &errs.Errorf{
+				Type:      errf.Type,
+				Message:   "The delta _last_checkpoint hint was ignored : " + errf.Message,
+				ReturnRaw: true,
+			})
+		} else {
+			first := slices.IndexFunc(checkpoints, func(cp deltaCheckpointParts) bool { return cp.version == hint.Version })
+			for i := first; first >= 0 && i < len(checkpoints) && checkpoints[i].version == hint.Version; i++ {
+				if hint.Parts == 0 && len(checkpoints[i].keys) == 1 || int64(len(checkpoints[i].keys)) == hint.Parts {
+					checkpoints[first], checkpoints[i] = checkpoints[i], checkpoints[first]
+					break
+				}
+			}
+		}
+	}
+
+	// a table without commit 0 (cleaned up) can only be built from a checkpoint.
+	if _, ok := commits[0]; ok {
+		checkpoints = append(checkpoints, deltaCheckpointParts{version: -1})
+	}
+
+	var lastErr *errs.Errorf
+	for _, cp := range checkpoints {
+
+		// every commit after the checkpoint is needed.
+		contiguous := true
+		for v := cp.version + 1; v <= latest; v++ {
+			if _, ok := commits[v]; !ok {
+				contiguous = false
+				break
+			}
+		}
+		if !contiguous {
+			continue
+		}
+
+		var checkpoint *deltaformats.DeltaCheckpoint
+		if cp.version >= 0 {
+			var errf *errs.Errorf
+			checkpoint, errf = readCheckpoint(ctx, store, cp)
+			if errf != nil {
+				lastErr = errf
+				continue
+			}
+		}
+
+		logs, errf := readCommits(ctx, store, commits, cp.version)
+		if errf != nil {
+			return errf
+		}
+
+		snap := deltautils.NewSnapshot()
+		if checkpoint != nil {
+			deltautils.ApplyLog(snap, checkpoint.Log)
+		}
+		for i := len(logs) - 1; i >= 0; i-- {
+			if logs[i].Version > cp.version {
+				deltautils.ApplyLog(snap, logs[i])
+			}
+		}
+
+		table.Delta.Checkpoint = checkpoint
+		table.Delta.Log = logs
+		table.Delta.Snapshot = snap
+
+		// a newer checkpoint could not be read, the snapshot was rebuilt from an older one (or the full log).
+		if lastErr != nil {
+			table.Errors = append(table.Errors, &errs.Errorf{
+				Type:      lastErr.Type,
+				Message:   "A newer delta checkpoint was skipped : " + lastErr.Message,
+				ReturnRaw: true,
+			})
+		}
+
+		return nil
+	}
+
+	errf := &errs.Errorf{
+		Type:      errs.ErrInvalidInput,
+		Message:   "The delta log has no usable checkpoint with all commits after it, the table state can not be reconstructed.",
+		ReturnRaw: true,
+	}
+	if lastErr != nil {
+		errf.Message += " Last checkpoint error : " + lastErr.Message
+	}
+
+	return errf
+}
+
+// completeCheckpoints groups the given checkpoint files into checkpoints, skipping the ones with missing parts.
+//
+// Returned newest first.
+func completeCheckpoints(keys []string) []deltaCheckpointParts {
+
+	type group struct {
+		version int64
+		parts   map[int64]string
+		total   int64
+	}
+
+	groups := make(map[string]*group)
+	for _, key := range keys {
+		version, part, parts, ok := deltautils.ParseCheckpoint(key)
+		if !ok {
+			continue
+		}
+
+		// the same version may have been checkpointed more than once, with a different number of parts.
+		id := fmt.Sprintf("%d.%d", version, parts)
+		g, ok := groups[id]
+		if !ok {
+			g = &group{
+				version: version,
+				parts:   make(map[int64]string),
+				total:   parts,
+			}
+			groups[id] = g
+		}
+		g.parts[part] = key
+	}
+
+	checkpoints := make([]deltaCheckpointParts, 0)
+	for _, g := range groups {
+		if int64(len(g.parts)) != g.total {
+			continue
+		}
+
+		cp := deltaCheckpointParts{
+			version: g.version,
+		}
+		for part := int64(1); part <= g.total; part++ {
+			cp.keys = append(cp.keys, g.parts[part])
+		}
+		checkpoints = append(checkpoints, cp)
+	}
+
+	slices.SortFunc(checkpoints, func(a, b deltaCheckpointParts) int {
+		if c := cmp.Compare(b.version, a.version); c != 0 {
+			return c
+		}
+		return len(a.keys) - len(b.keys)
+	})
+
+	return checkpoints
+}
+
+func readLastCheckpoint(ctx context.Context, store objstore.ObjectStore, key string) (*deltaformats.DeltaLastCheckpoint, *errs.Errorf) {
+
+	filePath, errf := fetcher.FetchNdSave(ctx, store, key, "")
+	if errf != nil {
+		return nil, errf
+	}
+
+	return deltautils.ReadLastCheckpoint(filePath)
+}
+
+// readCheckpoint downloads all the parts of the given checkpoint concurrently and reads them in order.
+func readCheckpoint(ctx context.Context, store objstore.ObjectStore, cp deltaCheckpointParts) (*deltaformats.DeltaCheckpoint, *errs.Errorf) {
+
+	filePaths := make([]string, len(cp.keys))
+	fetchErrs := make([]*errs.Errorf, len(cp.keys))
+
+	var wg sync.WaitGroup
+	for i, key := range cp.keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			filePaths[i], fetchErrs[i] = fetcher.FetchNdSave(ctx, store, key, "")
+		}(i, key)
+	}
+	wg.Wait()
+
+	log := &deltaformats.DeltaLog{
+		Version: cp.version,
+		Key:     cp.keys[0],
+	}
+
+	for i, filePath := range filePaths {
+		if fetchErrs[i] != nil {
+			return nil, fetchErrs[i]
+		}
+
+		errf := deltautils.ReadCheckpoint(filePath, log)
+		if errf != nil {
+			return nil, errf
+		}
+	}
+
+	return &deltaformats.DeltaCheckpoint{
+		Version: cp.version,
+		Keys:    cp.keys,
+		Log:     log,
+	}, nil
+}
+
+// readCommits reads every commit after the given version and older ones until the history limit is met.
+//
+// Returned newest first.
+func readCommits(ctx context.Context, store objstore.ObjectStore, commits map[int64]string, after int64) ([]*deltaformats.DeltaLog, *errs.Errorf) {
+
+	versions := make([]int64, 0, len(commits))
+	for version := range commits {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	slices.Reverse(versions)
+
+	toRead := make([]int64, 0)
+	for _, version := range versions {
+		if version <= after && len(toRead) >= configs.Extras.DeltaCommitsLimit {
+			break
+		}
+		toRead = append(toRead, version)
+	}
+
+	logs := make([]*deltaformats.DeltaLog, len(toRead))
+	readErrs := make([]*errs.Errorf, len(toRead))
+
+	var wg sync.WaitGroup
+	for i, version := range toRead {
+		wg.Add(1)
+		go func(i int, version int64) {
+			defer wg.Done()
+
+			filePath, errf := fetcher.FetchNdSave(ctx, store, commits[version], "")
+			if errf != nil {
+				readErrs[i] = errf
+				return
+			}
+
+			log, errf := deltautils.ReadMetadata(filePath)
+			if errf != nil {
+				readErrs[i] = errf
+				return
+			}
+			log.Version = version
+			log.Key = commits[version]
+			logs[i] = log
+		}(i, version)
+	}
+	wg.Wait()
+
+	for _, errf := range readErrs {
+		if errf != nil {
+			return nil, &errs.Errorf{
+				Type:      errf.Type,
+				Message:   "Some delta commits could not be read : " + errf.Message,
+				ReturnRaw: true,
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+// dvOps reads the deletion vectors of the live files of the snapshot, to count the rows they delete.
+// The deletion vector files are downloaded once each (many vectors may share one), bounded by the config.
+//
+// A vector that can not be read is recorded in DVErrors, it never fails the table.
+func dvOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) {
+
+	type dvRef struct {
+		dataPath string
+		offset   int64
+	}
+
+	table.Delta.DVRows = make(map[string]int64)
+	table.Delta.DVErrors = make(map[string]string)
+
+	paths := make([]string, 0)
+	for filePath, file := range table.Delta.Snapshot.Files {
+		if file.Add.DeletionVector != nil {
+			paths = append(paths, filePath)
+		}
+	}
+	slices.Sort(paths)
+
+	// the vector files, by key, with the vectors they hold.
+	dvFiles := make(map[string][]dvRef)
+	order := make([]string, 0)
+
+	for _, dataPath := range paths {
+		dv := table.Delta.Snapshot.Files[dataPath].Add.DeletionVector
+
+		var fileRef string // the key of the vector file.
+		switch dv.StorageType {
+		case deltautils.DVStorageInline:
+			rows, errf := deltautils.InlineDVCardinality(dv)
+			if errf != nil {
+				table.Delta.DVErrors[dataPath] = errf.Message
+				continue
+			}
+			table.Delta.DVRows[dataPath] = rows
+			continue
+		case deltautils.DVStorageUUID:
+			relPath, errf := deltautils.DVRelativePath(dv)
+			if errf != nil {
+				table.Delta.DVErrors[dataPath] = errf.Message
+				continue
+			}
+			fileRef = table.URI + relPath
+		case deltautils.DVStoragePath:
+			key, found := objstore.KeyFromURI(store, dv.PathOrInlineDv)
+			if !found {
+				table.Delta.DVErrors[dataPath] = "The deletion vector path does not belong to the location : " + dv.PathOrInlineDv
+				continue
+			}
+			fileRef = key
+		default:
+			table.Delta.DVErrors[dataPath] = "Unknown deletion vector storage type : " + dv.StorageType
+			continue
+		}
+
+		if _, ok := dvFiles[fileRef]; !ok {
+			if len(order) >= configs.Extras.DeltaDVFilesLimit {
+				continue
+			}
+			order = append(order, fileRef)
+		}
+
+		// the offset defaults to 1, right after the format version byte.
+		offset := int64(1)
+		if dv.Offset != nil {
+			offset = *dv.Offset
+		}
+		dvFiles[fileRef] = append(dvFiles[fileRef], dvRef{dataPath: dataPath, offset: offset})
+	}
+
+	filePaths := make([]string, len(order))
+	fetchErrs := make([]*errs.Errorf, len(order))
+
+	var wg sync.WaitGroup
+	for i, fileRef := range order {
+		wg.Add(1)
+		go func(i int, fileRef string) {
+			defer wg.Done()
+			filePaths[i], fetchErrs[i] = fetcher.FetchNdSave(ctx, store, fileRef, "")
+		}(i, fileRef)
+	}
+	wg.Wait()
+
+	for i, fileRef := range order {
+		for _, ref := range dvFiles[fileRef] {
+			if fetchErrs[i] != nil {
+				table.Delta.DVErrors[ref.dataPath] = fetchErrs[i].Message
+				continue
+			}
+
+			rows, errf := deltautils.ReadDVCardinality(filePaths[i], ref.offset)
+			if errf != nil {
+				table.Delta.DVErrors[ref.dataPath] = errf.Message
+				continue
+			}
+			table.Delta.DVRows[ref.dataPath] = rows
+		}
+	}
+}
+
+// cdcOps reads the change data files of the latest commits read, bounded by the config.
+//
+// A file that can not be read is recorded in CDCErrors, it never fails the table.
+func cdcOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) {
+
+	type cdcRef struct {
+		version int64
+		path    string
+		key     string
+	}
+
+	table.Delta.CDCFiles = make(map[string]*deltaformats.DeltaCDCFile)
+	table.Delta.CDCErrors = make(map[string]string)
+
+	// the log is newest first.
+	refs := make([]cdcRef, 0)
+	for _, log := range table.Delta.Log {
+		for _, cdc := range log.CDC {
+			if len(refs) >= configs.Extras.DeltaCDCFilesLimit {
+				break
+			}
+
+			key, errf := deltaFileKey(store, table, cdc.Path)
+			if errf != nil {
+				table.Delta.CDCErrors[cdc.Path] = errf.Message
+				continue
+			}
+			refs = append(refs, cdcRef{version: log.Version, path: cdc.Path, key: key})
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, ref := range refs {
+		wg.Add(1)
+		go func(ref cdcRef) {
+			defer wg.Done()
+
+			cdcFile, errf := readCDCFile(ctx, store, ref.key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if errf != nil {
+				table.Delta.CDCErrors[ref.path] = errf.Message
+				return
+			}
+			cdcFile.Version = ref.version
+			cdcFile.Path = ref.path
+			table.Delta.CDCFiles[ref.path] = cdcFile
+		}(ref)
+	}
+	wg.Wait()
+}
+
+func readCDCFile(ctx context.Context, store objstore.ObjectStore, key string) (*deltaformats.DeltaCDCFile, *errs.Errorf) {
+
+	filePath, errf := fetcher.FetchNdSave(ctx, store, key, "")
+	if errf != nil {
+		return nil, errf
+	}
+
+	return deltautils.ReadCDCFile(filePath, configs.Extras.DeltaCDCRowsLimit)
+}
+
+// deltaFileKey returns the key of the file at the given path of an action, either relative to the table
+// root (url encoded) or an absolute uri.
+func deltaFileKey(store objstore.ObjectStore, table *dto.Table, filePath string) (string, *errs.Errorf) {
+
+	if strings.Contains(filePath, "://") {
+		key, found := objstore.KeyFromURI(store, filePath)
+		if !found {
+			return "", &errs.Errorf{
+				Type:    errs.ErrBadForm,
+				Message: "The file path does not belong to the location : " + filePath,
+			}
+		}
+		return key, nil
+	}
+
+	relPath, err := url.PathUnescape(filePath)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrBadForm,
+			Message: "Failed to unescape the file path : " + err.Error(),
+		}
+	}
+
+	return table.URI + relPath, nil
+}
@@ -0,0 +1,174 @@
+package pipeline
+
+import (
+	"context"
+	"lakelens/internal/adapters/engine/fetcher"
+	"lakelens/internal/adapters/objstore"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	hudiformats "lakelens/internal/dto/formats/hudi"
+	hudiutils "lakelens/internal/utils/hudi"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// stateRank orders the states of a single instant, the completed one being the latest.
+var stateRank = map[string]int{
+	hudiutils.StateRequested: 0,
+	hudiutils.StateInflight:  1,
+	hudiutils.StateCompleted: 2,
+}
+
+// HandleHudi handles downloading, reading and extraction of the properties and timeline of the given Hudi table.
+func HandleHudi(ctx context.Context, store objstore.ObjectStore, table *dto.Table) (bool, *errs.Errorf) {
+
+	// only the top level is listed, the metadata table, archived timeline, etc are not needed.
+	resp, err := objstore.ListAll(ctx, store, table.Hudi.URI, "/")
+	if err != nil {
+		return false, &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to list objects : " + err.Error(),
+		}
+	}
+
+	objects := resp.Objects
+
+	// timeline layout 2 (hudi 1.x) keeps the instants in their own folder.
+	timelineURI := table.Hudi.URI + consts.HudiTimelineFolder
+	if slices.Contains(resp.Prefixes, timelineURI) {
+		timeline, err := objstore.ListAll(ctx, store, timelineURI, "/")
+		if err != nil {
+			return false, &errs.Errorf{
+				Type:    errs.ErrServiceUnavailable,
+				Message: "Failed to list timeline objects : " + err.Error(),
+			}
+		}
+		objects = append(objects, timeline.Objects...)
+	}
+
+	for _, obj := range objects {
+
+		_, fname := path.Split(obj.Key)
+		if fname == consts.HudiPropsFile {
+			table.Hudi.PropsFPath = obj.Key
+			continue
+		}
+
+		instant, ok := hudiutils.ParseInstant(fname)
+		if !ok {
+			continue
+		}
+		instant.Key = obj.Key
+
+		table.Hudi.TimelineFPaths = append(table.Hudi.TimelineFPaths, obj.Key)
+		table.Hudi.Timeline = append(table.Hudi.Timeline, instant)
+	}
+
+	// every state of an instant has its own file, only the latest state of each instant is kept.
+	slices.SortStableFunc(table.Hudi.Timeline, func(a, b *hudiformats.HudiInstant) int {
+		if c := strings.Compare(a.Timestamp, b.Timestamp); c != 0 {
+			return c
+		}
+		return stateRank[a.State] - stateRank[b.State]
+	})
+	latest := table.Hudi.Timeline[:0]
+	for i, instant := range table.Hudi.Timeline {
+		if i+1 < len(table.Hudi.Timeline) && table.Hudi.Timeline[i+1].Timestamp == instant.Timestamp {
+			continue
+		}
+		latest = append(latest, instant)
+	}
+	table.Hudi.Timeline = latest
+
+	table.Errors = runOps([]func() *errs.Errorf{
+		func() *errs.Errorf { return propsOps(ctx, store, table) },
+		func() *errs.Errorf { return timelineOps(ctx, store, table) },
+	})
+
+	return false, nil
+}
+
+func propsOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	if table.Hudi.PropsFPath == "" {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No 'hoodie.properties' file was found.",
+			ReturnRaw: true,
+		}
+	}
+
+	filePath, errf := fetcher.FetchNdSave(ctx, store, table.Hudi.PropsFPath, "")
+	if errf != nil {
+		return errf
+	}
+
+	props, errf := hudiutils.ReadProperties(filePath)
+	if errf != nil {
+		return errf
+	}
+	table.Hudi.Properties = props
+
+	return nil
+}
+
+// timelineOps reads the commit metadata of the latest completed commits, bounded by the config.
+func timelineOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	toRead := make([]*hudiformats.HudiInstant, 0)
+	for i := len(table.Hudi.Timeline) - 1; i >= 0 && len(toRead) < configs.Extras.HudiCommitsLimit; i-- {
+		instant := table.Hudi.Timeline[i]
+		if instant.State == hudiutils.StateCompleted && hudiutils.IsCommitAction(instant.Action) {
+			toRead = append(toRead, instant)
+		}
+	}
+
+	if len(toRead) == 0 {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr *errs.Errorf
+	read := 0
+
+	for _, instant := range toRead {
+		wg.Add(1)
+
+		go func(instant *hudiformats.HudiInstant) {
+			defer wg.Done()
+
+			filePath, errf := fetcher.FetchNdSave(ctx, store, instant.Key, "")
+			if errf == nil {
+				instant.Commit, errf = hudiutils.ReadCommitMetadata(filePath)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if errf != nil {
+				if firstErr == nil {
+					firstErr = errf
+				}
+				return
+			}
+			read++
+		}(instant)
+	}
+	wg.Wait()
+
+	table.Hudi.CommitsRead = read
+
+	if firstErr != nil {
+		return &errs.Errorf{
+			Type:      firstErr.Type,
+			Message:   "Some hudi commits could not be read : " + firstErr.Message,
+			ReturnRaw: true,
+		}
+	}
+
+	return nil
+}
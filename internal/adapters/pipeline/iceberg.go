@@ -0,0 +1,388 @@
+package pipeline
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"lakelens/internal/adapters/engine/fetcher"
+	"lakelens/internal/adapters/objstore"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HandleIceberg handles downloading, reading and extraction of metadata of the given Iceberg table.
+//
+// The snapshots history and the objects listing are only built if opts asks for the deep or orphan scan.
+func HandleIceberg(ctx context.Context, store objstore.ObjectStore, table *dto.Table, opts dto.ScanOptions) (bool, *errs.Errorf) {
+
+	resp, err := objstore.ListAll(ctx, store, table.Iceberg.URI, "")
+	if err != nil {
+		return false, &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to list objects : " + err.Error(),
+		}
+	}
+
+	for _, obj := range resp.Objects {
+
+		key := obj.Key
+		if strings.HasSuffix(key, ".metadata.json") {
+			table.Iceberg.MetadataFPaths = append(table.Iceberg.MetadataFPaths, key)
+		} else if path.Ext(key) == ".avro" {
+			if _, fname := path.Split(key); strings.HasPrefix(fname, "snap-") {
+				table.Iceberg.SnapshotFPaths = append(table.Iceberg.SnapshotFPaths, key)
+			} else {
+				table.Iceberg.ManifestFPaths = append(table.Iceberg.ManifestFPaths, key)
+			}
+		}
+	}
+
+	table.Errors = runOps([]func() *errs.Errorf{
+		func() *errs.Errorf { return metaOps(ctx, store, table) },
+		func() *errs.Errorf { return snapOps(ctx, store, table) },
+		func() *errs.Errorf { return maniOps(ctx, store, table) },
+	})
+
+	if opts.IcebergDeepScan || opts.IcebergOrphanScan {
+		if errf := histOps(ctx, store, table, opts.IcebergOrphanScan); errf != nil {
+			table.Errors = append(table.Errors, errf)
+		}
+	}
+
+	if opts.IcebergOrphanScan {
+		if errf := listOps(ctx, store, table); errf != nil {
+			table.Errors = append(table.Errors, errf)
+		}
+	}
+
+	return false, nil
+}
+
+func runOps(tasks []func() *errs.Errorf) []*errs.Errorf {
+
+	var errsCollected []*errs.Errorf
+
+	for _, task := range tasks {
+		if errf := task(); errf != nil {
+			errsCollected = append(errsCollected, errf)
+		}
+	}
+
+	return errsCollected
+}
+
+func metaOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	// the latest metadata file is the one of the highest version, the names do not sort as their versions
+	// (v10.metadata.json is before v9.metadata.json).
+	slices.SortFunc(table.Iceberg.MetadataFPaths, iceutils.CompareMetadataFiles)
+	metaLen := len(table.Iceberg.MetadataFPaths)
+
+	if metaLen <= 0 {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No '.metadata.json' metadata files were found.",
+			ReturnRaw: true,
+		}
+	}
+
+	filePath, errf := fetcher.FetchNdSave(ctx, store, table.Iceberg.MetadataFPaths[metaLen-1], "")
+	if errf != nil {
+		return errf
+	}
+
+	metadata, errf := iceutils.ReadMetadata(filePath)
+	if errf != nil {
+		return errf
+	}
+
+	table.Iceberg.Metadata = metadata
+
+	return nil
+}
+
+func snapOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	if table.Iceberg.Metadata == nil {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No metadata was read, cannot resolve the current snapshot.",
+			ReturnRaw: true,
+		}
+	}
+
+	var snapPath string
+	snaps := table.Iceberg.Metadata.Snapshots
+
+	if len(snaps) <= 0 {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No 'snap-*.avro' snapshot files were found.",
+			ReturnRaw: true,
+		}
+	}
+
+	currSnapID := table.Iceberg.Metadata.CurrentSnapshotID
+	for _, snap := range snaps {
+		if snap.SnapshotID == currSnapID {
+			snapPath = snap.ManifestList
+			break
+		}
+	}
+	if snapPath == "" {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   fmt.Sprintf("The current snapshot %d is not in the metadata snapshots (or has no manifest list).", currSnapID),
+			ReturnRaw: true,
+		}
+	}
+
+	filePath, errf := fetcher.FetchNdSave(ctx, store, tableKey(table, snapPath), snapPath)
+	if errf != nil {
+		return errf
+	}
+
+	snap, errf := iceutils.ReadSnapshot(filePath)
+	if errf != nil {
+		return errf
+	}
+
+	table.Iceberg.Snapshot = append(table.Iceberg.Snapshot, snap)
+
+	return nil
+}
+
+func maniOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	snaps := table.Iceberg.Snapshot
+	if len(snaps) <= 0 {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No '.avro' manifest files were found.",
+			ReturnRaw: true,
+		}
+	}
+
+	snapRecords := snaps[0].Records
+	if len(snapRecords) <= 0 {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No '.avro' manifest files were found.",
+			ReturnRaw: true,
+		}
+	}
+
+	data := make([]*formats.ManifestData, 0)
+
+	for _, record := range snapRecords {
+
+		filePath, errf := fetcher.FetchNdSave(ctx, store, tableKey(table, record.ManifestPath), record.ManifestPath)
+		if errf != nil {
+			return errf
+		}
+
+		entries, errf := iceutils.ReadManifest(filePath)
+		if errf != nil {
+			return errf
+		}
+		entries.URI = record.ManifestPath
+
+		data = append(data, entries)
+	}
+
+	table.Iceberg.Manifest = append(table.Iceberg.Manifest, &formats.IcebergManifest{Data: data})
+
+	return nil
+}
+
+// histOps walks the manifest lists of the latest retained snapshots (bounded by the config) and reads every
+// manifest they list once, shared manifests are deduplicated by path. The manifests of the current snapshot
+// are already read by maniOps.
+//
+// With all, every retained snapshot is walked. The orphan scan needs it, a file only reachable from a
+// snapshot not walked would be reported as an orphan.
+//
+// A snapshot that can not be walked is recorded in its Error, the others are still walked.
+func histOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table, all bool) *errs.Errorf {
+
+	if table.Iceberg.Metadata == nil {
+		return &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "No metadata was read, cannot walk the snapshots history.",
+			ReturnRaw: true,
+		}
+	}
+
+	snaps := slices.Clone(table.Iceberg.Metadata.Snapshots)
+	slices.SortFunc(snaps, func(a, b formats.IcebergMetadataSnapshot) int {
+		return cmp.Compare(b.TimestampMS, a.TimestampMS)
+	})
+
+	limit := configs.Extras.IcebergSnapshotsLimit
+	if all {
+		limit = len(snaps)
+	}
+
+	history := &formats.IcebergHistory{
+		Snapshots: make(map[int64]*formats.IcebergSnapshotFiles),
+		Manifests: make(map[string]*formats.ManifestData),
+		Partial:   len(snaps) > limit,
+	}
+	if len(snaps) > limit {
+		snaps = snaps[:limit]
+	}
+
+	for _, mani := range table.Iceberg.Manifest {
+		for _, data := range mani.Data {
+			history.Manifests[data.URI] = data
+		}
+	}
+
+	// the manifest lists first, then the manifests not read yet. Each one is downloaded and decoded by its own
+	// goroutine, at most as many at once as the requests allowed to the provider.
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, configs.Extras.ProviderConcurrency(store.Provider()))
+	for _, snap := range snaps {
+		files := &formats.IcebergSnapshotFiles{
+			SnapshotID:  snap.SnapshotID,
+			TimestampMS: snap.TimestampMS,
+		}
+		history.Snapshots[snap.SnapshotID] = files
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(manifestList string, files *formats.IcebergSnapshotFiles) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			filePath, errf := fetcher.FetchNdSave(ctx, store, tableKey(table, manifestList), manifestList)
+			if errf != nil {
+				files.Error = errf.Message
+				return
+			}
+
+			snapshot, errf := iceutils.ReadSnapshot(filePath)
+			if errf != nil {
+				files.Error = errf.Message
+				return
+			}
+			files.Manifests = snapshot.Records
+		}(snap.ManifestList, files)
+	}
+	wg.Wait()
+
+	toRead := make(map[string]bool)
+	for _, files := range history.Snapshots {
+		for _, record := range files.Manifests {
+			if _, ok := history.Manifests[record.ManifestPath]; !ok {
+				toRead[record.ManifestPath] = true
+			}
+		}
+	}
+
+	failed := make(map[string]string)
+	for manifestPath := range toRead {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(manifestPath string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			filePath, errf := fetcher.FetchNdSave(ctx, store, tableKey(table, manifestPath), manifestPath)
+			if errf == nil {
+				var data *formats.ManifestData
+				data, errf = iceutils.ReadManifest(filePath)
+				if errf == nil {
+					data.URI = manifestPath
+					mu.Lock()
+					history.Manifests[manifestPath] = data
+					mu.Unlock()
+					return
+				}
+			}
+
+			mu.Lock()
+			failed[manifestPath] = errf.Message
+			mu.Unlock()
+		}(manifestPath)
+	}
+	wg.Wait()
+
+	for _, files := range history.Snapshots {
+		for _, record := range files.Manifests {
+			if msg, ok := failed[record.ManifestPath]; ok && files.Error == "" {
+				files.Error = "Failed to read manifest " + record.ManifestPath + " : " + msg
+			}
+		}
+
+		if files.Error != "" {
+			history.Partial = true
+			continue
+		}
+		history.SnapshotsRead++
+		iceutils.CountSnapshotFiles(files, history.Manifests)
+	}
+
+	table.Iceberg.History = history
+
+	return nil
+}
+
+// listOps lists every object under the table root for the orphan scan, the files of the table are matched to
+// them by their paths relative to the root.
+func listOps(ctx context.Context, store objstore.ObjectStore, table *dto.Table) *errs.Errorf {
+
+	// taken before listing, an object written during the listing is younger than it.
+	listedAt := time.Now().UnixMilli()
+
+	resp, err := objstore.ListAll(ctx, store, table.URI, "")
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrServiceUnavailable,
+			Message: "Failed to list the table objects : " + err.Error(),
+		}
+	}
+
+	listing := &formats.IcebergListing{
+		ListedAtMS: listedAt,
+		Objects:    make([]formats.IcebergObject, 0, len(resp.Objects)),
+	}
+	for _, obj := range resp.Objects {
+		listing.Objects = append(listing.Objects, formats.IcebergObject{
+			Key:            obj.Key,
+			Size:           obj.Size,
+			LastModifiedMS: obj.LastModified.UnixMilli(),
+		})
+	}
+
+	table.Iceberg.Listing = listing
+
+	return nil
+}
+
+// tableKey resolves a file uri of the table metadata to its key under the table root, using the location of the
+// metadata. So the files of a table copied from another bucket (or prefix) are read from where the copy is.
+//
+// Returns an empty key for the files out of the table location, FetchNdSave resolves them from the uri.
+func tableKey(table *dto.Table, uri string) string {
+
+	if table.Iceberg.Metadata == nil {
+		return ""
+	}
+
+	rel, ok := iceutils.RelativePath(table.Iceberg.Metadata.Location, uri)
+	if !ok {
+		return ""
+	}
+
+	return table.URI + rel
+}
@@ -1,8 +1,10 @@
 package pipeline
 
 import (
+	"context"
 	"fmt"
-	"lakelens/internal/adapters/s3/engine/fetcher"
+	"lakelens/internal/adapters/engine/fetcher"
+	"lakelens/internal/adapters/objstore"
 	configs "lakelens/internal/config"
 	"lakelens/internal/consts"
 	"lakelens/internal/consts/errs"
@@ -11,45 +13,47 @@ import (
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/gin-gonic/gin"
 )
 
-func HandleParquet(ctx *gin.Context, client *s3.Client, newBucket *dto.NewBucket) (bool, *errs.Errorf) {
-
-	resp, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-		Bucket: &newBucket.Data.Name,
-	})
-	if err != nil {
-		return false, &errs.Errorf{
-			Type:    errs.ErrServiceUnavailable,
-			Message: "Failed to list parquet objects : " + err.Error(),
-		}
-	}
+func HandleParquet(ctx context.Context, store objstore.ObjectStore, newBucket *dto.NewBucket) (bool, *errs.Errorf) {
 
 	limit := configs.Extras.ParquetFilesLimit
 	latestUpdate := time.Time{}
-
-	for _, obj := range resp.Contents {
-		if limit <= 0 {
-			break
+	token := ""
+
+	// pages are only fetched till the limit is reached.
+	for limit > 0 {
+		resp, err := store.List(ctx, newBucket.Data.Prefix, "", token)
+		if err != nil {
+			return false, &errs.Errorf{
+				Type:    errs.ErrServiceUnavailable,
+				Message: "Failed to list parquet objects : " + err.Error(),
+			}
 		}
 
-		//
-		// fmt.Printf("%s : %s : %s\n", *obj.Key, obj.LastModified, newBucket.Data.UpdatedAt)
-		if obj.LastModified.After(latestUpdate) {
-			latestUpdate = *obj.LastModified
-		}
-		//
+		for _, obj := range resp.Objects {
+			if limit <= 0 {
+				break
+			}
+
+			//
+			// fmt.Printf("%s : %s : %s\n", *obj.Key, obj.LastModified, newBucket.Data.UpdatedAt)
+			if obj.LastModified.After(latestUpdate) {
+				latestUpdate = obj.LastModified
+			}
+			//
 
-		if obj.Key != nil {
-			key := *obj.Key
-			if key[len(key)-1] != '/' && strings.HasSuffix(key, consts.ParquetFileExt) {
+			key := obj.Key
+			if key != "" && key[len(key)-1] != '/' && strings.HasSuffix(key, consts.ParquetFileExt) {
 				newBucket.Parquet.AllFilePaths = append(newBucket.Parquet.AllFilePaths, key)
 				limit--
 			}
 		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		token = resp.NextToken
 	}
 
 	if !latestUpdate.After(newBucket.Data.UpdatedAt) && !latestUpdate.IsZero() {
@@ -65,7 +69,7 @@ func HandleParquet(ctx *gin.Context, client *s3.Client, newBucket *dto.NewBucket
 		go func(path string) {
 			defer wg.Done()
 
-			filePath, errf := fetcher.DownloadSingleParquetS3(ctx, client, newBucket.Data.Name, path)
+			filePath, errf := fetcher.DownloadSingleParquet(ctx, store, path)
 			if errf != nil {
 				// TODO: handle error, retry logic
 				return
@@ -1,14 +1,86 @@
 package configs
 
+import "lakelens/internal/consts"
+
 type ExtraCfg struct {
 	DetermineTableTypeMaxDepth int32
 
 	ParquetFilesLimit int32
+
+	// the max number of tables cataloged per location and how many of them are extracted at once.
+	TablesPerLocLimit int
+	TablesConcurrency int
+
+	// the max number of latest completed hudi commits whose metadata is read.
+	HudiCommitsLimit int
+
+	// the min number of delta commits read for the history, the commits after the checkpoint are always read.
+	DeltaCommitsLimit int
+	// the max number of delta deletion vector files read, the inline ones are always read.
+	DeltaDVFilesLimit int
+	// the max number of delta change data files read (latest commits first) and the rows kept of each.
+	DeltaCDCFilesLimit int
+	DeltaCDCRowsLimit int
+
+	// the snapshots walked by the iceberg deep scan (opted in per analyze request), the latest ones first.
+	IcebergSnapshotsLimit int
+	// the merge-on-read limits flagging a partition for a rewrite, its deleted to data records ratio and its
+	// number of position (or equality) delete files.
+	IcebergDeleteRatioLimit float64
+	IcebergDeleteFilesLimit int
+	// the partitions per page of the partition explorer, and the records over the mean making a partition hot.
+	IcebergPartitionsPageSize int
+	IcebergHotPartitionSkew float64
+	// the objects modified less than IcebergOrphanMinAgeHours before the listing of the iceberg orphan scan
+	// (opted in per analyze request) may be in-flight writes, so they are never reported as orphans.
+	IcebergOrphanMinAgeHours int
+	IcebergOrphansPageSize int
+
+	// the max number of requests (list, get, etc) in flight at once for a single lake scan, per provider.
+	// providers not in the map get ListConcurrencyDefault.
+	ListConcurrency map[string]int
+	ListConcurrencyDefault int
 }
 
 func InitExtraCfg() ExtraCfg {
 	return ExtraCfg{
 		DetermineTableTypeMaxDepth: 10,
 		ParquetFilesLimit: 12,
+
+		TablesPerLocLimit: 200,
+		TablesConcurrency: 4,
+
+		HudiCommitsLimit: 50,
+
+		DeltaCommitsLimit: 50,
+		DeltaDVFilesLimit: 100,
+		DeltaCDCFilesLimit: 20,
+		DeltaCDCRowsLimit: 100,
+
+		IcebergSnapshotsLimit: 100,
+		IcebergDeleteRatioLimit: 0.1,
+		IcebergDeleteFilesLimit: 10,
+		IcebergPartitionsPageSize: 50,
+		IcebergHotPartitionSkew: 3,
+		IcebergOrphanMinAgeHours: 72,
+		IcebergOrphansPageSize: 100,
+
+		ListConcurrency: map[string]int{
+			consts.AWSS3: 16,
+			consts.MinIO: 8,
+			consts.Azure: 16,
+			consts.GCS: 16,
+			consts.Local: 4,
+		},
+		ListConcurrencyDefault: 8,
+	}
+}
+
+// ProviderConcurrency returns the request concurrency for the given provider, never less than 1.
+func (c ExtraCfg) ProviderConcurrency(provider string) int {
+	n, ok := c.ListConcurrency[provider]
+	if !ok {
+		n = c.ListConcurrencyDefault
 	}
+	return max(n, 1)
 }
\ No newline at end of file
@@ -3,7 +3,10 @@ package configs
 type DownSavePaths struct {
 	BaseSavePath string
 
-	S3DownPath string
+	S3DownPath    string
+	AzureDownPath string
+	GCSDownPath   string
+	LocalDownPath string
 }
 
 type PathsCfg struct {
@@ -19,6 +22,11 @@ type PathsCfg struct {
 	// < Down Save Paths
 	DownSavePaths DownSavePaths
 	// >
+
+	// < Local Lakes
+	// only directories under these roots can be registered as local (file://) lakes.
+	LocalLakeAllowedRoots []string
+	// >
 }
 
 func InitPathsCfg() PathsCfg {
@@ -29,8 +37,15 @@ func InitPathsCfg() PathsCfg {
 		RequestLoggerFilePath: "./logs/requests.log",
 
 		DownSavePaths: DownSavePaths{
-			BaseSavePath: "./lakeDownloads",
-			S3DownPath:   "./lakeDownloads" + "/s3",
+			BaseSavePath:  "./lakeDownloads",
+			S3DownPath:    "./lakeDownloads" + "/s3",
+			AzureDownPath: "./lakeDownloads" + "/azure",
+			GCSDownPath:   "./lakeDownloads" + "/gcs",
+			LocalDownPath: "./lakeDownloads" + "/local",
+		},
+
+		LocalLakeAllowedRoots: []string{
+			"./localLakes",
 		},
 	}
 }
@@ -9,6 +9,8 @@ const (
 	HudiTable    = "hudi"
 
 	UnknownFile = "unknown"
+	// MixedTables is the location table type when it holds tables of different types.
+	MixedTables = "mixed"
 )
 
 // General storage type names. Do Not Change.
@@ -16,8 +18,22 @@ const (
 	AWSS3 = "awsS3"
 	Azure = "azure"
 	MinIO = "minIO"
+	GCS   = "gcs"
+	Local = "local"
 )
 
+// The region used for S3-compatible stores when none is given, most of them ignore it anyway.
+const DefaultS3CompatRegion = "us-east-1"
+
+// Azure credential types, stored in credentials.cred_type.
+const (
+	AzureSharedKey = "sharedKey" // key_id is the account name, key is the account key.
+	AzureSASToken  = "sasToken"  // key_id is the account name, key is the SAS token.
+)
+
+// The default Azure blob service endpoint, formatted with the account name.
+const AzureBlobEndpointFmt = "https://%s.blob.core.windows.net/"
+
 const (
 	EPassAuth   = "epass"
 	GoogleOAuth = "goauth"
@@ -38,6 +54,11 @@ const (
 	IcebergDataFolder = "/data/"
 
 	DeltaLogFolder = "/_delta_log/"
+	// points to the latest checkpoint, it may be missing or stale.
+	DeltaLastCheckpointFile = "_last_checkpoint"
 
 	HudiMetaFolder = "/.hoodie/"
+	// the timeline moved into its own folder with timeline layout 2 (hudi 1.x).
+	HudiTimelineFolder = "timeline/"
+	HudiPropsFile      = "hoodie.properties"
 )
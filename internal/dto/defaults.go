@@ -44,6 +44,7 @@ type LocResp struct {
 	LocID      int64
 	LakeID     int64
 	BucketName string
+	Prefix     string // empty if the location is the whole bucket.
 	CreatedAt  time.Time
 }
 
@@ -116,19 +117,36 @@ type LakeFileDist struct {
 type LocCheckResp struct {
 	LocID       int64
 	BucketName  string
+	Prefix      string
 	AuthCheck   bool
 	PolicyCheck bool
 	ReadCheck   bool
 	WriteCheck  bool
 }
 
+// TableResp is one table detected inside a location.
+type TableResp struct {
+	ID          string
+	TableType   string
+	URI         string
+	ErrorsCount int
+}
+
+type LocTablesResp struct {
+	LocID     int64
+	TableType string // the common type of all tables, or mixed.
+	Tables    []*TableResp
+}
+
 type NewLake struct {
 	Name string // the lake project name, whatever the user wants.
 
 	// only one is valid, others remain nil.
 	S3    *NewLakeS3
+	MinIO *NewLakeMinIO
 	Azure *NewLakeAzure
 	GCP   *NewLakeGCP
+	Local *NewLakeLocal
 }
 
 type NewLakeS3 struct {
@@ -137,12 +155,39 @@ type NewLakeS3 struct {
 	LakeRegion string
 }
 
+// NewLakeMinIO is used for any S3-compatible store (MinIO, Ceph RGW, etc).
+type NewLakeMinIO struct {
+	AccessID   string
+	AccessKey  string
+	LakeRegion string // optional, defaults to us-east-1.
+
+	Endpoint           string // the full endpoint url, like https://minio.internal:9000
+	UsePathStyle       bool
+	InsecureSkipVerify bool
+}
+
+// NewLakeAzure is used for Azure Blob Storage and ADLS Gen2 accounts.
+// Exactly one of AccountKey or SASToken is required.
 type NewLakeAzure struct {
-	// TODO:
+	AccountName string
+	AccountKey  string
+	SASToken    string // account level SAS, with or without the leading '?'.
+	LakeRegion  string // optional, only for display.
+
+	Endpoint string // optional, the blob service url, like http://127.0.0.1:10000/devstoreaccount1 for Azurite.
 }
 
+// NewLakeGCP is used for Google Cloud Storage, the project is taken from the service-account key.
 type NewLakeGCP struct {
-	// TODO:
+	ServiceAccountJSON string // the complete service-account JSON key file contents.
+	LakeRegion         string // optional, only for display.
+
+	Endpoint string // optional, the json api url, like http://127.0.0.1:4443/storage/v1/ for fake-gcs-server.
+}
+
+// NewLakeLocal is used for directories on the server disk or NFS mounts, every sub directory is a location.
+type NewLakeLocal struct {
+	RootURI string // file:///abs/path or just /abs/path, must be under one of the allowed roots.
 }
 
 type NewLakeResp struct {
@@ -152,7 +197,7 @@ type NewLakeResp struct {
 
 type AddLocsReq struct {
 	LakeID   int64
-	LocNames []string
+	LocNames []string // bucket names, or bucket/prefix/ (the scheme like s3:// is optional) to only add a prefix.
 }
 
 type AddLocsResp struct {
@@ -261,9 +306,14 @@ type SchemaList struct {
 
 type SchemaField struct {
 	ID       int64
-	Name     string
+	Name     string // the dotted path of nested fields.
 	Required bool
 	Type     string
+
+	ParentID       int64 // the id of the struct, list or map holding the field, 0 at the top level.
+	Doc            string
+	InitialDefault any
+	WriteDefault   any
 }
 
 type Schema struct {
@@ -279,6 +329,34 @@ type SchemaData struct {
 	SchemaID         int64
 	RelatedSnapshots []int64
 	ColumnSizes      map[string]int64 // column name to size in bytes
+
+	Columns []*ColumnRange // the ranges over the data files of the current snapshot.
+	Files   []*FileRange
+}
+
+// The bounds of the string and binary columns may be truncated by the writers, the upper ones rounded up.
+type ValueRange struct {
+	Min any
+	Max any
+}
+
+type ColumnRange struct {
+	ID              int64
+	Name            string
+	Type            string
+	Min             any // nil when no file has a bound.
+	Max             any
+	NullCount       int64
+	ValueCount      int64
+	FilesWithBounds int64
+	Error           string // why the bounds of the column could not be decoded.
+}
+
+type FileRange struct {
+	Path        string
+	Partition   string
+	RecordCount int64
+	Columns     map[string]*ValueRange // column name to its range in the file.
 }
 
 type ColSize struct {
@@ -294,3 +372,473 @@ type SchemaColSizes struct {
 	SchemaID int64
 	ColSizes []ColSize
 }
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+type HudiOverview struct {
+	FoundAt            string // the uri where the table was found.
+	TableName          string
+	DatabaseName       string
+	TableType          string // COPY_ON_WRITE or MERGE_ON_READ
+	TableVersion       string
+	TimelineLayout     string
+	BaseFileFormat     string
+	RecordKeyFields    []string
+	PrecombineField    string
+	PartitionFields    []string
+	KeyGenerator       string
+	MetadataPartitions []string
+	InstantCounts      map[string]int64 // action to the number of instants on the active timeline.
+	PendingInstants    int64            // the requested or inflight instants.
+	FirstInstant       string
+	LatestInstant      string
+	LatestCommit       string // the latest completed instant that wrote data.
+	CommitsRead        int
+}
+
+type HudiHistoryEntry struct {
+	Timestamp         string
+	CompletionTime    string
+	Action            string
+	State             string
+	OperationType     string
+	PartitionsWritten int64
+	FilesWritten      int64
+	NumWrites         int64
+	NumInserts        int64
+	NumUpdates        int64
+	NumDeletes        int64
+	BytesWritten      int64
+	WriteErrors       int64
+	MetadataRead      bool // false if the commit metadata was not read, the counts are then zero.
+}
+
+type HudiHistory struct {
+	Instants []*HudiHistoryEntry // newest first.
+}
+
+type HudiPartitionFileGroups struct {
+	Partition       string
+	FileGroups      int64
+	BaseFiles       int64
+	LogFiles        int64
+	TotalSize       int64
+	AvgBaseFileSize int64
+}
+
+type HudiFileGroups struct {
+	TotalFileGroups int64
+	TotalBaseFiles  int64
+	TotalLogFiles   int64
+	TotalSize       int64
+	Partitions      []*HudiPartitionFileGroups
+	CommitsRead     int
+	Partial         bool // true if not every completed commit was read, the stats only cover the read ones.
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+type DeltaProtocolResp struct {
+	MinReaderVersion int64
+	MinWriterVersion int64
+	ReaderFeatures   []string
+	WriterFeatures   []string
+}
+
+type DeltaVersionState struct {
+	Version         int64
+	Timestamp       int64 // the commit timestamp of the version, in ms.
+	Operation       string
+	EarliestVersion int64 // the oldest version that can be reconstructed from the scanned log.
+	LatestVersion   int64
+
+	Protocol         DeltaProtocolResp
+	Schema           []*SchemaField
+	PartitionColumns []string
+	Configuration    map[string]string
+
+	NumFiles     int64
+	SizeInBytes  int64
+	NumRecords   int64
+	FilesNoStats int64 // the live files without stats, their records are not counted.
+}
+
+type DeltaColStats struct {
+	Path           string // the dotted path of the column, nested struct fields are flattened.
+	Type           string
+	NullCount      int64
+	ValueCount     int64 // the records of the files with a null count for the column.
+	NullRatio      float64
+	Min            any
+	Max            any
+	FilesWithStats int64
+	WideBounds     int64 // the files whose bounds are not tight (deletion vectors), their min/max may be wider than the data.
+}
+
+type DeltaFileRows struct {
+	Files        int64
+	FilesNoStats int64 // the files without stats, not part of the row counts.
+	MinRows      int64
+	MaxRows      int64
+	AvgRows      int64
+	MedianRows   int64
+}
+
+type DeltaSchemaColStats struct {
+	SchemaID   int64
+	NumRecords int64
+	FileRows   DeltaFileRows
+	Columns    []*DeltaColStats
+}
+
+type DeltaDVFile struct {
+	Path        string
+	StorageType string
+	SizeInBytes int64 // the size of the serialized bitmap.
+	NumRecords  int64 // the physical records of the data file, as per its stats.
+	DeletedRows int64 // the cardinality of the descriptor.
+	ReadRows    int64 // the cardinality of the bitmap read, -1 if it was not read.
+	Error       string
+}
+
+type DeltaDeletionVectors struct {
+	Enabled      bool // the deletionVectors feature is supported by the protocol.
+	Version      int64
+	LiveFiles    int64
+	FilesWithDVs int64
+	InlineDVs    int64
+	DeletedRows  int64 // the rows deleted as per the descriptors.
+	ReadRows     int64 // the rows deleted as per the bitmaps read.
+	FilesNotRead int64 // the files whose bitmap was not read, because of the files limit.
+	Mismatches   int64 // the files whose bitmap cardinality is not the one of the descriptor.
+	Files        []*DeltaDVFile
+}
+
+type DeltaFeature struct {
+	Name             string
+	Reader           bool // required by readers, else only by writers.
+	Legacy           bool // implied by the legacy protocol versions, not listed in the features.
+	Known            bool
+	Description      string
+	DeltaSparkReader string // the first Delta Lake (Spark) release able to read it.
+}
+
+type DeltaEngineSupport struct {
+	Engine  string
+	Release string
+	CanRead bool
+}
+
+type DeltaProtocolAudit struct {
+	Protocol          DeltaProtocolResp
+	TableFeatures     bool // the protocol lists its features (reader 3 / writer 7) instead of the legacy versions.
+	Features          []*DeltaFeature
+	UnknownFeatures   []string // the reader features not known, the engine support does not account for them.
+	MinDeltaSparkRead string
+	Engines           []*DeltaEngineSupport
+}
+
+type DeltaChangeCount struct {
+	Files int64 // the files with changes of the type, a change data file counts once under its main type.
+	Bytes int64 // the size of a change data file is split between its types by their rows.
+	Rows  int64 // -1 if not known, some data files have no stats.
+}
+
+type DeltaVersionChanges struct {
+	Version   int64
+	Timestamp int64
+	Operation string
+	Source    string // cdc (change data files), add/remove (derived from the data files) or none.
+
+	Insert          DeltaChangeCount
+	UpdatePreimage  DeltaChangeCount
+	UpdatePostimage DeltaChangeCount
+	Delete          DeltaChangeCount
+
+	CDCFiles        int64
+	CDCBytes        int64
+	CDCFilesNotRead int64 // the change data files not read, not part of the per type counts.
+}
+
+type DeltaChangeFeed struct {
+	Enabled         bool // delta.enableChangeDataFeed is set in the latest metadata.
+	EarliestVersion int64
+	LatestVersion   int64
+	Versions        []*DeltaVersionChanges // newest first.
+}
+
+type DeltaChangeRows struct {
+	From          int64
+	To            int64
+	Rows          []map[string]any // with _change_type, _commit_version and _commit_timestamp.
+	Truncated     bool             // some change data files have more rows than the ones kept.
+	FilesNotRead  []string         // the change data files of the range not read.
+	VersionsNoCDC []int64          // the versions of the range without change data files, their changes are the data files.
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+type IcebergFileChanges struct {
+	AddedFiles    int64
+	DeletedFiles  int64
+	ExistingFiles int64
+
+	AddedRecords    int64
+	DeletedRecords  int64
+	ExistingRecords int64
+
+	AddedBytes    int64
+	DeletedBytes  int64
+	ExistingBytes int64
+}
+
+type IcebergSnapshotHistory struct {
+	SnapshotID       int64
+	ParentSnapshotID int64
+	TimestampMS      int64
+	Operation        string
+	Manifests        int64
+	Error            string // why the snapshot could not be walked, its counts are empty then.
+
+	Data    IcebergFileChanges
+	Deletes IcebergFileChanges
+}
+
+type IcebergHistory struct {
+	SnapshotsRead int
+	ManifestsRead int // the distinct manifests read, shared by the snapshots.
+	Partial       bool
+	Snapshots     []*IcebergSnapshotHistory // newest first.
+}
+
+type IcebergDiffFile struct {
+	Path            string
+	Content         string // data, position_deletes or equality_deletes.
+	Partition       string
+	RecordCount     int64
+	FileSizeInBytes int64
+}
+
+type IcebergFieldChange struct {
+	ID     int64
+	Name   string
+	Change string // added, dropped, renamed, type_promoted, type_changed, required, reordered or moved.
+	From   string
+	To     string
+	Legal  bool   // whether the iceberg spec allows the change.
+	Reason string // why the change is not allowed.
+}
+
+type IcebergSnapshotDiff struct {
+	FromSnapshotID  int64
+	ToSnapshotID    int64
+	FromTimestampMS int64
+	ToTimestampMS   int64
+
+	DataFilesAdded     []*IcebergDiffFile
+	DataFilesRemoved   []*IcebergDiffFile
+	DeleteFilesAdded   []*IcebergDiffFile
+	DeleteFilesRemoved []*IcebergDiffFile
+
+	RecordsAdded   int64 // the records of the data files, the delete files are not applied.
+	RecordsRemoved int64
+	NetRecords     int64
+	BytesAdded     int64 // the bytes of the data and delete files.
+	BytesRemoved   int64
+	NetBytes       int64
+
+	PartitionsTouched []string
+
+	SchemaChanged bool
+	FromSchemaID  int64
+	ToSchemaID    int64
+	SchemaChanges []*IcebergFieldChange
+
+	SpecChanged bool
+	FromSpecIDs []int64 // the partition specs of the data manifests of the snapshot.
+	ToSpecIDs   []int64
+}
+
+type IcebergSchemaDiff struct {
+	FromSchemaID int64
+	ToSchemaID   int64
+	Legal        bool           // whether every change is allowed by the iceberg spec.
+	Counts       map[string]int // change to its number of fields.
+	Changes      []*IcebergFieldChange
+}
+
+type IcebergRef struct {
+	Name        string
+	Type        string // branch or tag.
+	SnapshotID  int64
+	TimestampMS int64 // of its snapshot, 0 when the snapshot is not in the metadata anymore.
+	SchemaID    int64 // the schema its reads use, the current one for branches and the snapshot one for tags.
+	Snapshots   int   // the snapshots of its ancestry still in the metadata.
+	AheadOfMain int   // the snapshots of its ancestry not in the main ancestry, like unpublished audit commits.
+	BehindMain  int   // the snapshots of the main ancestry not in its ancestry.
+
+	// the retention set on the ref, nil when it falls back to the table properties (or defaults).
+	MaxRefAgeMS        *int64
+	MinSnapshotsToKeep *int64
+	MaxSnapshotAgeMS   *int64
+
+	// the retention applied to the ref, a zero max age never expires.
+	EffectiveMaxRefAgeMS        int64
+	EffectiveMinSnapshotsToKeep int64
+	EffectiveMaxSnapshotAgeMS   int64
+	Expired                     bool // whether the ref is older than its max age, the next snapshot expiry removes it.
+}
+
+type IcebergRefs struct {
+	CurrentSnapshotID int64
+	Refs              []*IcebergRef // main first, then by name.
+}
+
+type IcebergRefOverview struct {
+	Ref    *IcebergRef
+	Stats  *OverviewStats
+	Schema *OverviewSchema
+}
+
+type IcebergPartitionDeletes struct {
+	SpecID    int64
+	Partition string // the values as name=value pairs, like ts_day=2024-03-09/bucket=3 .
+	Values    []*IcebergPartitionValue
+
+	DataFiles   int64
+	DataRecords int64
+	DataBytes   int64
+
+	PositionDeleteFiles   int64
+	PositionDeleteRecords int64
+	PositionDeleteBytes   int64
+
+	EqualityDeleteFiles   int64
+	EqualityDeleteRecords int64
+	EqualityDeleteBytes   int64
+	EqualityFieldIDs      []int64 // the fields the equality deletes match rows on.
+
+	DeleteRatio       float64 // the delete records over the data records.
+	ReadAmplification float64 // the bytes read over the data bytes, each delete file read once.
+	MaxMergedDeletes  int64   // the delete files a data file may be merged with on read, at worst all of them.
+
+	RewriteDataFiles       bool // the deletes should be applied by rewrite_data_files.
+	RewritePositionDeletes bool // the position delete files should be compacted by rewrite_position_delete_files.
+	Reasons                []string
+}
+
+type IcebergDeleteReport struct {
+	SnapshotID       int64
+	Total            *IcebergPartitionDeletes // over every partition, it has no partition and is never flagged.
+	UrgentPartitions int                      // the partitions flagged for a rewrite.
+	Partitions       []*IcebergPartitionDeletes
+}
+
+type IcebergPartitionValue struct {
+	Name      string
+	Transform string
+	SourceID  int64
+	Value     string // decoded per its transform, like 2024-03-09 for a day.
+}
+
+type IcebergPartitionStats struct {
+	SpecID    int64
+	Partition string // the values as name=value pairs, like ts_day=2024-03-09/bucket=3 .
+	Values    []*IcebergPartitionValue
+
+	DataFiles     int64
+	Records       int64
+	Bytes         int64
+	DeleteFiles   int64
+	DeleteRecords int64
+
+	LastSnapshotID int64 // the latest snapshot adding or removing its files.
+	LastModifiedMS int64 // 0 when that snapshot expired.
+
+	Skew float64 // its records over the mean records per partition.
+	Hot  bool
+}
+
+type IcebergPartitions struct {
+	SnapshotID      int64
+	TotalPartitions int
+	Offset          int
+	Limit           int
+	MeanRecords     float64
+	SkewScore       float64 // the coefficient of variation of the records per partition, 0 when even.
+	HotPartitions   int
+	Partitions      []*IcebergPartitionStats // the page, the most records first.
+}
+
+type IcebergLayoutUsage struct {
+	SnapshotIDs      []int64 // the snapshots that used it, oldest first.
+	FirstSnapshotID  int64
+	FirstTimestampMS int64
+	LastSnapshotID   int64
+	LastTimestampMS  int64
+
+	LiveDataFiles int64 // the live data files of the current snapshot written with it.
+	LiveDataBytes int64
+	LiveRecords   int64
+}
+
+type IcebergSpecVersion struct {
+	SpecID  int64
+	Default bool
+	Fields  []*OverviewPartitionField
+	Usage   IcebergLayoutUsage // the snapshots whose manifest lists have manifests of the spec.
+}
+
+type IcebergSortField struct {
+	Transform  string
+	SourceID   int64
+	SourceName string
+	Direction  string // asc or desc.
+	NullOrder  string // nulls-first or nulls-last.
+}
+
+type IcebergSortOrderVersion struct {
+	OrderID int64
+	Default bool
+	Fields  []*IcebergSortField // none for the unsorted order.
+	Usage   IcebergLayoutUsage  // the snapshots that added data files with the sort order.
+}
+
+type IcebergLayoutTimeline struct {
+	SnapshotsRead int  // the manifest lists read, only the current one without the deep scan.
+	Partial       bool // some retained snapshots were not read.
+
+	Specs      []*IcebergSpecVersion      // by spec id.
+	SortOrders []*IcebergSortOrderVersion // by order id.
+
+	OldSpecFiles          int64 // the live data files still written with a spec other than the default one.
+	OldSpecBytes          int64
+	OldSortOrderFiles     int64 // the live data files written with a sort order other than the default one.
+	OldSortOrderBytes     int64
+	UnknownSortOrderFiles int64 // the live data files without a sort order id.
+}
+
+type IcebergOrphanFile struct {
+	Key            string
+	Kind           string // data, metadata or other, by the folder under the table root.
+	SizeBytes      int64
+	LastModifiedMS int64
+	AgeMS          int64 // at the listing.
+}
+
+type IcebergOrphans struct {
+	ListedAtMS     int64
+	MinAgeMS       int64
+	Partial        bool // some retained snapshots were not walked, only the provable orphans are reported.
+	ObjectsListed  int
+	ReachableFiles int // the listed objects reachable from the metadata.
+	RecentFiles    int // the unreachable objects younger than the min age, maybe in-flight writes.
+	RecentBytes    int64
+	WithheldFiles  int // the unreachable objects not reported as the walk was partial, they may still be reachable.
+	WithheldBytes  int64
+	OrphanFiles    int
+	OrphanBytes    int64
+	Offset         int
+	Limit          int
+	Orphans        []*IcebergOrphanFile // the page, the largest first.
+}
@@ -2,6 +2,7 @@ package formats
 
 import (
 	deltaformats "lakelens/internal/dto/formats/delta"
+	hudiformats "lakelens/internal/dto/formats/hudi"
 	icebergformats "lakelens/internal/dto/formats/iceberg"
 	parquetformats "lakelens/internal/dto/formats/parquet"
 )
@@ -17,6 +18,8 @@ type IsIceberg struct {
 	Metadata       *icebergformats.IcebergMetadata
 	Snapshot       []*icebergformats.IcebergSnapshot
 	Manifest       []*icebergformats.IcebergManifest
+	History        *icebergformats.IcebergHistory // only with the deep scan, nil otherwise.
+	Listing        *icebergformats.IcebergListing // only with the orphan scan, nil otherwise.
 }
 
 type IsParquet struct {
@@ -26,13 +29,27 @@ type IsParquet struct {
 }
 
 type IsHudi struct {
-	Present bool
+	Present        bool
+	URI            string // the .hoodie/ folder of the table.
+	PropsFPath     string
+	TimelineFPaths []string
+	Properties     *hudiformats.HudiProperties
+	Timeline       []*hudiformats.HudiInstant // sorted by instant time, oldest first.
+	CommitsRead    int                        // the number of completed commits whose metadata was read.
 }
 
 type IsDelta struct {
-	Present   bool
-	URI       string
-	LogFPaths []string
-	CRCFPaths []string
-	Log       []*deltaformats.DeltaLog
+	Present             bool
+	URI                 string
+	LogFPaths           []string
+	CRCFPaths           []string
+	CheckpointFPaths    []string
+	LastCheckpointFPath string
+	Checkpoint          *deltaformats.DeltaCheckpoint         // the checkpoint the snapshot was built from, nil if none.
+	Log                 []*deltaformats.DeltaLog              // the commits read, newest first.
+	Snapshot            *deltaformats.DeltaSnapshot           // the latest state of the table, nil if it could not be built.
+	DVRows              map[string]int64                      // the rows deleted per live data file, as read from its deletion vector.
+	DVErrors            map[string]string                     // the deletion vectors that could not be read, per data file.
+	CDCFiles            map[string]*deltaformats.DeltaCDCFile // the change data files read, by path.
+	CDCErrors           map[string]string                     // the change data files that could not be read, by path.
 }
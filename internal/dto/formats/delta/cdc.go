@@ -1,3 +1,19 @@
 package formats
 
-// TODO: add the cdc file format for delta type here
\ No newline at end of file
+// DeltaCDC is a change data file, written by the commits of tables with the change data feed enabled.
+type DeltaCDC struct {
+	Path            string    `json:"path"` // relative to the table root, under _change_data/ .
+	PartitionValues any       `json:"partitionValues"`
+	Size            int64     `json:"size"`
+	DataChange      bool      `json:"dataChange"`
+	Tags            DeltaTags `json:"tags"`
+}
+
+// DeltaCDCFile is a change data file read during the scan.
+type DeltaCDCFile struct {
+	Version     int64
+	Path        string
+	NumRows     int64
+	ChangeTypes map[string]int64 // the number of rows per _change_type.
+	Rows        []map[string]any // the first rows of the file, bounded by the config.
+}
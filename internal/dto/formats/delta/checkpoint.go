@@ -0,0 +1,17 @@
+package formats
+
+// DeltaLastCheckpoint is the _last_checkpoint file, a hint to the latest checkpoint.
+type DeltaLastCheckpoint struct {
+	Version       int64 `json:"version"`
+	Size          int64 `json:"size"`
+	Parts         int64 `json:"parts"` // only set for multi-part checkpoints.
+	SizeInBytes   int64 `json:"sizeInBytes"`
+	NumOfAddFiles int64 `json:"numOfAddFiles"`
+}
+
+// DeltaCheckpoint is a single or multi-part checkpoint, with the actions of all of its parts.
+type DeltaCheckpoint struct {
+	Version int64
+	Keys    []string  // the keys of the parts, in order.
+	Log     *DeltaLog // the actions of all the parts, the version is the checkpoint version.
+}
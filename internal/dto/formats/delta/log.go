@@ -1,11 +1,15 @@
 package formats
 
 type DeltaLog struct {
+	Version int64  `json:"-"` // the commit version, parsed from the file name.
+	Key     string `json:"-"` // the key of the commit file in the store.
+
 	CommitInfo  DeltaCommitInfo `json:"commitInfo"`
 	Protocol    DeltaProtocol   `json:"protocol"`
 	Metadata    DeltaMetadata   `json:"metaData"`
 	Add         []DeltaAdd      `json:"add"`
 	Remove      []DeltaRemove   `json:"remove"`
+	CDC         []DeltaCDC      `json:"cdc"`
 	Transaction DeltaTxn        `json:"txn"`
 }
 
@@ -15,11 +19,13 @@ type DeltaLogSingle struct {
 	Metadata    *DeltaMetadata   `json:"metaData"`
 	Add         *DeltaAdd        `json:"add"`
 	Remove      *DeltaRemove     `json:"remove"`
+	CDC         *DeltaCDC        `json:"cdc"`
 	Transaction *DeltaTxn        `json:"txn"`
 }
 
 type DeltaCommitInfo struct {
 	Timestamp        int64                 `json:"timestamp"`
+	InCommitTS       int64                 `json:"inCommitTimestamp"` // only set with the inCommitTimestamp feature.
 	UserID           string                `json:"userId"`
 	UserName         string                `json:"userName"`
 	Operation        string                `json:"operation"`
@@ -62,13 +68,13 @@ type DeltaProtocol struct {
 }
 
 type DeltaMetadata struct {
-	ID               string             `json:"id"`
-	Format           DeltaFormat        `json:"format"`
-	SchemaString     string             `json:"schemaString"`
-	Schema           DeltaSchema        `json:"schema"`
-	PartitionColumns []any              `json:"partitionColumns"`
-	Configuration    DeltaConfiguration `json:"configuration"`
-	CreatedTime      int64              `json:"createdTime"`
+	ID               string            `json:"id"`
+	Format           DeltaFormat       `json:"format"`
+	SchemaString     string            `json:"schemaString"`
+	Schema           DeltaSchema       `json:"schema"`
+	PartitionColumns []any             `json:"partitionColumns"`
+	Configuration    map[string]string `json:"configuration"`
+	CreatedTime      int64             `json:"createdTime"`
 }
 type DeltaFormat struct {
 	Provider string `json:"provider"`
@@ -80,15 +86,11 @@ type DeltaSchema struct {
 }
 type DeltaSchemaField struct {
 	Name     string `json:"name"`
-	Type     string `json:"type"`
+	Type     any    `json:"type"` // the primitive type name, or a map for the struct, array and map types.
 	Nullable bool   `json:"nullable"`
 	Metadata any    `json:"metadata"`
 }
 
-type DeltaConfiguration struct {
-	AppendOnly bool `json:"appendOnly"`
-}
-
 type DeltaAdd struct {
 	Path                    string    `json:"path"`
 	PartitionValues         any       `json:"partitionValues"`
@@ -100,6 +102,17 @@ type DeltaAdd struct {
 	ClusteringProvider      string    `json:"clusteringProvider"`
 	Stats                   string    `json:"stats"`
 	Tags                    DeltaTags `json:"tags"`
+
+	DeletionVector *DeltaDeletionVector `json:"deletionVector"`
+}
+
+// DeltaDeletionVector is the descriptor of the rows deleted from a data file.
+type DeltaDeletionVector struct {
+	StorageType    string `json:"storageType"` // u (uuid relative path), i (inline) or p (absolute path).
+	PathOrInlineDv string `json:"pathOrInlineDv"`
+	Offset         *int64 `json:"offset"` // the offset of the bitmap in the file, not set for inline ones.
+	SizeInBytes    int64  `json:"sizeInBytes"`
+	Cardinality    int64  `json:"cardinality"`
 }
 type DeltaTags struct {
 	InsertionTime      string `json:"INSERTION_TIME"`
@@ -114,4 +127,14 @@ type DeltaRemove struct {
 	BaseRowID               int64  `json:"baseRowId"`
 	DefaultRowCommitVersion int64  `json:"defaultRowCommitVersion"`
 	DataChange              bool   `json:"dataChange"`
+	Size                    int64  `json:"size"`
+	Stats                   string `json:"stats"`
+}
+
+// DeltaStats is the per file statistics JSON kept as a string in DeltaAdd.Stats.
+type DeltaStats struct {
+	NumRecords int64          `json:"numRecords"`
+	MinValues  map[string]any `json:"minValues"`
+	MaxValues  map[string]any `json:"maxValues"`
+	NullCount  map[string]any `json:"nullCount"`
 }
@@ -0,0 +1,25 @@
+package formats
+
+// DeltaSnapshot is the state of the table at a single version, reconstructed from the log.
+type DeltaSnapshot struct {
+	Version   int64
+	Timestamp int64 // the commit timestamp of the version, 0 if only the checkpoint was read.
+	Protocol  DeltaProtocol
+	Metadata  DeltaMetadata
+
+	Files       map[string]*DeltaFile // the live files, by path.
+	SizeInBytes int64
+	NumRecords  int64 // the live records, without the rows deleted by deletion vectors.
+	DeletedRows int64 // the rows deleted by deletion vectors, as per their descriptors.
+	NoStats     int64 // the number of live files without stats, their records are not counted.
+
+	RecordsDelta int64 // the records added minus removed by the latest commit applied.
+}
+
+// DeltaFile is a live file of the table.
+type DeltaFile struct {
+	Add         DeltaAdd
+	NumRecords  int64 // the physical records, as per the stats.
+	DeletedRows int64 // the cardinality of the deletion vector, if any.
+	HasStats    bool
+}
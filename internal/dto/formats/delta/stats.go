@@ -0,0 +1,21 @@
+package formats
+
+// DeltaFileStats is the per file stats of an add action, typed against the table schema.
+type DeltaFileStats struct {
+	NumRecords  int64
+	TightBounds *bool                        // nil if not written, false if deletion vectors made the bounds wide.
+	Columns     map[string]*DeltaColumnStats // by the dotted path of the (leaf) column.
+}
+
+// DeltaColumnStats is the stats of a single leaf column of a single file.
+//
+// Min and Max are int64 for the integral types, float64 for float and double, bool for boolean
+// and string for everything else (including decimal, date and timestamp), nil if not collected.
+type DeltaColumnStats struct {
+	Path         string
+	Type         string
+	Min          any
+	Max          any
+	NullCount    int64
+	HasNullCount bool
+}
@@ -0,0 +1,18 @@
+package formats
+
+// HudiProperties is the parsed .hoodie/hoodie.properties file of a hudi table.
+type HudiProperties struct {
+	TableName          string
+	DatabaseName       string
+	TableType          string // COPY_ON_WRITE or MERGE_ON_READ
+	TableVersion       string
+	TimelineLayout     string
+	BaseFileFormat     string
+	RecordKeyFields    []string
+	PrecombineField    string
+	PartitionFields    []string
+	KeyGenerator       string
+	CreateSchema       string
+	MetadataPartitions []string
+	Raw                map[string]string // every property as found in the file.
+}
@@ -0,0 +1,45 @@
+package formats
+
+// Structs used for the timeline of hudi tables, every file in the timeline folder is an instant.
+
+type HudiInstant struct {
+	Timestamp      string // the requested time of the instant, like 20240101120000123
+	CompletionTime string // only set by timeline layout 2 (hudi 1.x) for completed instants.
+	Action         string // commit, deltacommit, clean, compaction, rollback, etc
+	State          string // requested, inflight or completed
+	FileName       string
+	Key            string              // the object key of the instant file.
+	Commit         *HudiCommitMetadata // only for completed commit like instants that were read.
+}
+
+// HudiCommitMetadata is the JSON content of completed commit, deltacommit and replacecommit instants.
+type HudiCommitMetadata struct {
+	PartitionToWriteStats     map[string][]HudiWriteStat `json:"partitionToWriteStats"`
+	PartitionToReplaceFileIDs map[string][]string        `json:"partitionToReplaceFileIds"`
+	Compacted                 bool                       `json:"compacted"`
+	ExtraMetadata             map[string]string          `json:"extraMetadata"`
+	OperationType             string                     `json:"operationType"`
+}
+
+type HudiWriteStat struct {
+	FileID                       string   `json:"fileId"`
+	Path                         string   `json:"path"`
+	PrevCommit                   string   `json:"prevCommit"`
+	NumWrites                    int64    `json:"numWrites"`
+	NumDeletes                   int64    `json:"numDeletes"`
+	NumUpdateWrites              int64    `json:"numUpdateWrites"`
+	NumInserts                   int64    `json:"numInserts"`
+	TotalWriteBytes              int64    `json:"totalWriteBytes"`
+	TotalWriteErrors             int64    `json:"totalWriteErrors"`
+	PartitionPath                string   `json:"partitionPath"`
+	TotalLogRecords              int64    `json:"totalLogRecords"`
+	TotalLogFilesCompacted       int64    `json:"totalLogFilesCompacted"`
+	TotalLogSizeCompacted        int64    `json:"totalLogSizeCompacted"`
+	TotalUpdatedRecordsCompacted int64    `json:"totalUpdatedRecordsCompacted"`
+	TotalLogBlocks               int64    `json:"totalLogBlocks"`
+	TotalCorruptLogBlock         int64    `json:"totalCorruptLogBlock"`
+	TotalRollbackBlocks          int64    `json:"totalRollbackBlocks"`
+	FileSizeInBytes              int64    `json:"fileSizeInBytes"`
+	BaseFile                     string   `json:"baseFile"`
+	LogFiles                     []string `json:"logFiles"`
+}
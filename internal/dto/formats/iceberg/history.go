@@ -0,0 +1,38 @@
+package formats
+
+// Structs built by the deep scan, walking the manifest lists of the retained snapshots.
+
+type IcebergHistory struct {
+	Snapshots map[int64]*IcebergSnapshotFiles // by snapshot id.
+	Manifests map[string]*ManifestData        // every manifest read, by path. Snapshots share most of them.
+
+	SnapshotsRead int
+	Partial       bool // some retained snapshots were not walked, because of the limit or a failure.
+}
+
+// IcebergSnapshotFiles is the view of a single snapshot, as per its manifest list.
+type IcebergSnapshotFiles struct {
+	SnapshotID  int64
+	TimestampMS int64
+	Manifests   []*SnapshotRecord // the manifest list records, the manifests are in IcebergHistory.Manifests.
+	Error       string            // why the snapshot could not be walked, if so.
+
+	Data    IcebergFileCounts
+	Deletes IcebergFileCounts
+}
+
+// IcebergFileCounts counts the files of a snapshot by their manifest entry status.
+// Added and deleted are the entries written by the snapshot itself, existing are the live files it carries over.
+type IcebergFileCounts struct {
+	AddedFiles    int64
+	DeletedFiles  int64
+	ExistingFiles int64
+
+	AddedRecords    int64
+	DeletedRecords  int64
+	ExistingRecords int64
+
+	AddedBytes    int64
+	DeletedBytes  int64
+	ExistingBytes int64
+}
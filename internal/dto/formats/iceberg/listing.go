@@ -0,0 +1,14 @@
+package formats
+
+// Structs built by the orphan scan, listing every object under the table root.
+
+type IcebergListing struct {
+	ListedAtMS int64
+	Objects    []IcebergObject
+}
+
+type IcebergObject struct {
+	Key            string // the key in the location, under the table root.
+	Size           int64
+	LastModifiedMS int64
+}
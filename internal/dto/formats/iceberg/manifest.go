@@ -41,7 +41,7 @@ type ManifestDataFile struct {
 	KeyMetadata  string         `json:"key_metadata"`
 	SplitOffsets map[string]any `json:"split_offsets"`
 	EqualityIDs  map[string]any `json:"equality_ids"`
-	SortOrderID  string         `json:"sort_order_id"`
+	SortOrderID  *int64         `json:"sort_order_id"` // nil when the writer did not set one.
 
 	Partition map[string]any
 }
@@ -32,10 +32,13 @@ type IcebergSchema struct {
 	Fields   []IcebergSchemaField `json:"fields"`
 }
 type IcebergSchemaField struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	Required bool   `json:"required"`
-	Type     string `json:"type"`
+	ID             int64       `json:"id"`
+	Name           string      `json:"name"`
+	Required       bool        `json:"required"`
+	Type           IcebergType `json:"type"`
+	Doc            string      `json:"doc,omitempty"`
+	InitialDefault any         `json:"initial-default,omitempty"` // the value of the rows written before the field was added.
+	WriteDefault   any         `json:"write-default,omitempty"`   // the value written when the writer does not set one.
 }
 
 type IcebergPartitionSpec struct {
@@ -54,29 +57,42 @@ type IcebergSortOrder struct {
 	Fields  []IcebergSortOrderField `json:"fields"`
 }
 type IcebergSortOrderField struct {
+	Transform string  `json:"transform"`
+	SourceID  int64   `json:"source-id"`
+	SourceIDs []int64 `json:"source-ids,omitempty"` // the sources of the multi argument transforms (v3).
+	Direction string  `json:"direction"`            // asc or desc.
+	NullOrder string  `json:"null-order"`           // nulls-first or nulls-last.
 }
 
 type IcebergProperties struct {
 	Write_ObjectStorage_Enabled    string `json:"write.object-storage.enabled"`
 	Write_ObjectStorage_Path       string `json:"write.object-storage.path"`
 	Write_Parquet_CompressionCodec string `json:"write.parquet.compression-codec"`
-}
 
-type IcebergRefs struct {
-	Main IcebergRefsMain `json:"main"`
+	// the snapshot retention defaults of the branches without their own.
+	History_Expire_MaxSnapshotAgeMS   string `json:"history.expire.max-snapshot-age-ms"`
+	History_Expire_MinSnapshotsToKeep string `json:"history.expire.min-snapshots-to-keep"`
+	History_Expire_MaxRefAgeMS        string `json:"history.expire.max-ref-age-ms"`
 }
-type IcebergRefsMain struct {
-	SnapshotID int64  `json:"snapshot-id"`
-	Type       string `json:"type"`
+
+// IcebergRefs are the named branches and tags of the table, by name.
+type IcebergRefs map[string]IcebergRef
+type IcebergRef struct {
+	SnapshotID         int64  `json:"snapshot-id"`
+	Type               string `json:"type"`                            // branch or tag.
+	MaxRefAgeMS        *int64 `json:"max-ref-age-ms,omitempty"`        // branches and tags.
+	MinSnapshotsToKeep *int64 `json:"min-snapshots-to-keep,omitempty"` // branches only.
+	MaxSnapshotAgeMS   *int64 `json:"max-snapshot-age-ms,omitempty"`   // branches only.
 }
 
 type IcebergMetadataSnapshot struct {
-	SequenceNumber int64                  `json:"sequence-number"`
-	SnapshotID     int64                  `json:"snapshot-id"`
-	TimestampMS    int64                  `json:"timestamp-ms"`
-	Summary        IcebergSnapshotSummary `json:"summary"`
-	ManifestList   string                 `json:"manifest-list"`
-	SchemaID       int64                  `json:"schema-id"`
+	SequenceNumber   int64                  `json:"sequence-number"`
+	SnapshotID       int64                  `json:"snapshot-id"`
+	ParentSnapshotID int64                  `json:"parent-snapshot-id"`
+	TimestampMS      int64                  `json:"timestamp-ms"`
+	Summary          IcebergSnapshotSummary `json:"summary"`
+	ManifestList     string                 `json:"manifest-list"`
+	SchemaID         int64                  `json:"schema-id"`
 }
 type IcebergSnapshotSummary struct {
 	Operation                  string `json:"operation"`
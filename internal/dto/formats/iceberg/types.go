@@ -0,0 +1,126 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// The nested iceberg types, the others are primitive.
+const (
+	TypeStruct = "struct"
+	TypeList   = "list"
+	TypeMap    = "map"
+)
+
+// IcebergType is the type of a schema field, a primitive type (like long or decimal(10, 2)) or a nested
+// struct, list or map type. In the metadata, a primitive type is its name and a nested type an object.
+type IcebergType struct {
+	Primitive string // the name of a primitive type, empty for the nested types.
+	Nested    string // struct, list or map, empty for the primitive types.
+
+	// struct
+	Fields []IcebergSchemaField
+
+	// list
+	ElementID       int64
+	ElementRequired bool
+	Element         *IcebergType
+
+	// map
+	KeyID         int64
+	Key           *IcebergType
+	ValueID       int64
+	ValueRequired bool
+	Value         *IcebergType
+}
+
+type icebergNestedType struct {
+	Type   string               `json:"type"`
+	Fields []IcebergSchemaField `json:"fields,omitempty"`
+
+	ElementID       int64        `json:"element-id,omitempty"`
+	ElementRequired bool         `json:"element-required,omitempty"`
+	Element         *IcebergType `json:"element,omitempty"`
+
+	KeyID         int64        `json:"key-id,omitempty"`
+	Key           *IcebergType `json:"key,omitempty"`
+	ValueID       int64        `json:"value-id,omitempty"`
+	ValueRequired bool         `json:"value-required,omitempty"`
+	Value         *IcebergType `json:"value,omitempty"`
+}
+
+func (t *IcebergType) UnmarshalJSON(data []byte) error {
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		*t = IcebergType{}
+		return json.Unmarshal(data, &t.Primitive)
+	}
+
+	var nested icebergNestedType
+	err := json.Unmarshal(data, &nested)
+	if err != nil {
+		return err
+	}
+
+	*t = IcebergType{
+		Nested:          nested.Type,
+		Fields:          nested.Fields,
+		ElementID:       nested.ElementID,
+		ElementRequired: nested.ElementRequired,
+		Element:         nested.Element,
+		KeyID:           nested.KeyID,
+		Key:             nested.Key,
+		ValueID:         nested.ValueID,
+		ValueRequired:   nested.ValueRequired,
+		Value:           nested.Value,
+	}
+
+	return nil
+}
+
+func (t IcebergType) MarshalJSON() ([]byte, error) {
+
+	if t.Nested == "" {
+		return json.Marshal(t.Primitive)
+	}
+
+	return json.Marshal(icebergNestedType{
+		Type:            t.Nested,
+		Fields:          t.Fields,
+		ElementID:       t.ElementID,
+		ElementRequired: t.ElementRequired,
+		Element:         t.Element,
+		KeyID:           t.KeyID,
+		Key:             t.Key,
+		ValueID:         t.ValueID,
+		ValueRequired:   t.ValueRequired,
+		Value:           t.Value,
+	})
+}
+
+// String renders the type, the nested types like struct<a: int, b: list<string>> and map<string, long>.
+func (t *IcebergType) String() string {
+
+	if t == nil {
+		return ""
+	}
+
+	switch t.Nested {
+	case "":
+		return t.Primitive
+	case TypeStruct:
+		parts := make([]string, 0, len(t.Fields))
+		for _, field := range t.Fields {
+			parts = append(parts, field.Name+": "+field.Type.String())
+		}
+		return "struct<" + strings.Join(parts, ", ") + ">"
+	case TypeList:
+		return "list<" + t.Element.String() + ">"
+	case TypeMap:
+		return "map<" + t.Key.String() + ", " + t.Value.String() + ">"
+	}
+
+	return t.Nested
+}
@@ -3,6 +3,7 @@ package dto
 import (
 	"lakelens/internal/consts/errs"
 	"lakelens/internal/dto/formats"
+	"strconv"
 	"time"
 )
 
@@ -24,6 +25,7 @@ type GoodResp struct {
 
 type BucketData struct {
 	Name         string
+	Prefix       string // the prefix the location is scoped to, like teams/payments/, empty for the whole bucket.
 	StorageType  string
 	Region       *string
 	CreationDate *time.Time
@@ -32,14 +34,67 @@ type BucketData struct {
 	//
 	KeyCount int64
 	//
+	LakeID     int64
 	LocationID int64
+	TableCount int // the number of tables found in the location.
+}
+
+// ScanOptions are the opt-in (costlier) parts of a scan, bound per analyze request from its query,
+// like /analyze/12?IcebergDeepScan=true .
+type ScanOptions struct {
+	// IcebergDeepScan walks the manifest list of every retained snapshot, not only the current one.
+	// The snapshots walked are the latest ones, bounded by IcebergSnapshotsLimit.
+	IcebergDeepScan bool
+	// IcebergOrphanScan lists every object under the table roots and walks every retained snapshot,
+	// ignoring IcebergSnapshotsLimit.
+	IcebergOrphanScan bool
+}
+
+// CacheKey returns the key a scanned location is cached by.
+func (d *BucketData) CacheKey() string {
+	return CacheKey(d.LakeID, d.LocationID)
+}
+
+// CacheKey returns the key for a location given its lake and location ids, like 12/85 .
+//
+// Bucket names are only unique per endpoint (S3-compatible), storage account (azure) or directory (local),
+// so two lakes can hold same named locations and the cache is never keyed by the names.
+func CacheKey(lakeID, locID int64) string {
+	return strconv.FormatInt(lakeID, 10) + "/" + strconv.FormatInt(locID, 10)
+}
+
+// LocKey returns the key for a location given its bucket and prefix, like shared-bucket/teams/payments/
+func LocKey(bucket, prefix string) string {
+	if prefix == "" {
+		return bucket
+	}
+	return bucket + "/" + prefix
+}
+
+// Table is a single table found inside a location, a location can hold any number of them.
+type Table struct {
+	ID        string // stable id of the table inside its location, derived from the root uri.
+	TableType string
+	URI       string // the root prefix of the table in the location, like warehouse/db/table/
+	Iceberg   formats.IsIceberg
+	Delta     formats.IsDelta
+	Hudi      formats.IsHudi
+	Errors    []*errs.Errorf
 }
 
 type NewBucket struct {
 	Data    BucketData
-	Parquet formats.IsParquet
-	Iceberg formats.IsIceberg
-	Delta   formats.IsDelta
-	Hudi    formats.IsHudi
+	Tables  []*Table
+	Parquet formats.IsParquet // only used when no tables were found in the location.
 	Errors  []*errs.Errorf
 }
+
+// GetTable returns the table with the given id, nil if there is none.
+func (b *NewBucket) GetTable(tableID string) *Table {
+	for _, table := range b.Tables {
+		if table.ID == tableID {
+			return table
+		}
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package dto
 import (
 	"time"
 
+	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
@@ -12,3 +14,35 @@ type S3ClientSave struct {
 	LastUsed time.Time
 	S3Client *s3.Client
 }
+
+// S3ClientOpts holds the extra options needed to reach S3-compatible stores (MinIO, Ceph RGW, etc).
+// A nil or zero value builds a plain AWS S3 client.
+type S3ClientOpts struct {
+	Endpoint           string // the custom endpoint url, including the scheme.
+	UsePathStyle       bool   // use path-style addressing instead of virtual-hosted style.
+	InsecureSkipVerify bool   // skip tls certificate verification, for self-signed setups.
+}
+
+type AzureClientSave struct {
+	LastUsed    time.Time
+	AzureClient *azblob.Client
+}
+
+type GCSClientSave struct {
+	LastUsed  time.Time
+	GCSClient *storage.Client
+	ProjectID string
+}
+
+// GCSServiceAccount holds the fields of a service-account JSON key we care about.
+type GCSServiceAccount struct {
+	Type        string `json:"type"`
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+}
+
+type LocalClientSave struct {
+	LastUsed time.Time
+	Root     string // the absolute path of the lake root directory.
+}
@@ -0,0 +1,595 @@
+package delta
+
+import (
+	"fmt"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/services/delta"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeltaHandler struct {
+	Delta *delta.DeltaService
+}
+
+func NewDeltaHandler(delta *delta.DeltaService) *DeltaHandler {
+	return &DeltaHandler{
+		Delta: delta,
+	}
+}
+
+func (h *DeltaHandler) GetOverviewData(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetOverviewData(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetOverviewStats(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetOverviewStats(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetOverviewSchema(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetOverviewSchema(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetOverviewPartition(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetOverviewPartition(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetOverviewGraphs(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetOverviewGraphs(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *DeltaHandler) GetSchemasList(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetSchemasList(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetSchema(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	schemaid := ctx.Param("schemaid")
+	if schemaid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetSchema(ctx, userID, locid, tableid, schemaid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetSchemaColSizes(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	schemaid := ctx.Param("schemaid")
+	if schemaid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetSchemaColSizes(ctx, userID, locid, tableid, schemaid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetSchemaColStats(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	schemaid := ctx.Param("schemaid")
+	if schemaid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetSchemaColStats(ctx, userID, locid, tableid, schemaid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *DeltaHandler) GetVersion(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	version := ctx.Param("version")
+	if version == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetVersion(ctx, userID, locid, tableid, version)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetTimestamp(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	timestamp := ctx.Param("timestamp")
+	if timestamp == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetTimestamp(ctx, userID, locid, tableid, timestamp)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetDeletionVectors(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetDeletionVectors(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetProtocol(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetProtocol(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetChangeFeed(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetChangeFeed(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *DeltaHandler) GetChanges(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	from := ctx.Param("from")
+	to := ctx.Param("to")
+	if from == "" || to == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Delta.GetChanges(ctx, userID, locid, tableid, from, to)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
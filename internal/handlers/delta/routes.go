@@ -0,0 +1,63 @@
+package delta
+
+import (
+	"lakelens/internal/consts/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *DeltaHandler) RegisterRoutes(routegrp *gin.RouterGroup) {
+
+	routegrp.GET("/overview/data/:locid/:tableid", h.GetOverviewData)
+	routegrp.GET("/overview/stats/:locid/:tableid", h.GetOverviewStats)
+	routegrp.GET("/overview/schema/:locid/:tableid", h.GetOverviewSchema)
+	routegrp.GET("/overview/partition/:locid/:tableid", h.GetOverviewPartition)
+	routegrp.GET("/overview/graphs/:locid/:tableid", h.GetOverviewGraphs)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/schema/compare/list/:locid/:tableid", h.GetSchemasList)
+	routegrp.GET("/schema/compare/getschema/:locid/:tableid/:schemaid", h.GetSchema)
+	routegrp.GET("/schema/colsizes/:locid/:tableid/:schemaid", h.GetSchemaColSizes)
+	routegrp.GET("/schema/colstats/:locid/:tableid/:schemaid", h.GetSchemaColStats)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/version/:locid/:tableid/:version", h.GetVersion)
+	routegrp.GET("/timestamp/:locid/:tableid/:timestamp", h.GetTimestamp)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/deletionvectors/:locid/:tableid", h.GetDeletionVectors)
+	routegrp.GET("/protocol/:locid/:tableid", h.GetProtocol)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/cdf/summary/:locid/:tableid", h.GetChangeFeed)
+	routegrp.GET("/cdf/changes/:locid/:tableid/:from/:to", h.GetChanges)
+}
+
+// extractUserID extracts the user ID and other required parameters from the context with explicit type assertion.
+// any returned error is directly included in the response as returned
+func (h *DeltaHandler) getUserID(ctx *gin.Context) (int64, *errs.Errorf) {
+
+	userid, exists := ctx.Get("rid")
+	if !exists {
+		return 0, &errs.Errorf{
+			Type:      errs.ErrInvalidCredentials,
+			Message:   "Missing user ID in request.",
+			ReturnRaw: true,
+		}
+	}
+
+	userID, ok := userid.(int64)
+	if !ok {
+		return 0, &errs.Errorf{
+			Type:      errs.ErrInvalidFormat,
+			Message:   "User ID of improper format.",
+			ReturnRaw: true,
+		}
+	}
+
+	return userID, nil
+}
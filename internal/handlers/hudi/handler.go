@@ -0,0 +1,122 @@
+package hudi
+
+import (
+	"fmt"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/services/hudi"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HudiHandler struct {
+	Hudi *hudi.HudiService
+}
+
+func NewHudiHandler(hudi *hudi.HudiService) *HudiHandler {
+	return &HudiHandler{
+		Hudi: hudi,
+	}
+}
+
+func (h *HudiHandler) GetOverview(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Hudi.GetOverview(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *HudiHandler) GetHistory(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Hudi.GetHistory(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *HudiHandler) GetFileGroups(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Hudi.GetFileGroups(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,39 @@
+package hudi
+
+import (
+	"lakelens/internal/consts/errs"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *HudiHandler) RegisterRoutes(routegrp *gin.RouterGroup) {
+
+	routegrp.GET("/overview/:locid/:tableid", h.GetOverview)
+	routegrp.GET("/history/:locid/:tableid", h.GetHistory)
+	routegrp.GET("/filegroups/:locid/:tableid", h.GetFileGroups)
+}
+
+// extractUserID extracts the user ID and other required parameters from the context with explicit type assertion.
+// any returned error is directly included in the response as returned
+func (h *HudiHandler) getUserID(ctx *gin.Context) (int64, *errs.Errorf) {
+
+	userid, exists := ctx.Get("rid")
+	if !exists {
+		return 0, &errs.Errorf{
+			Type:      errs.ErrInvalidCredentials,
+			Message:   "Missing user ID in request.",
+			ReturnRaw: true,
+		}
+	}
+
+	userID, ok := userid.(int64)
+	if !ok {
+		return 0, &errs.Errorf{
+			Type:      errs.ErrInvalidFormat,
+			Message:   "User ID of improper format.",
+			ReturnRaw: true,
+		}
+	}
+
+	return userID, nil
+}
@@ -22,7 +22,8 @@ func NewIcebergHandler(iceberg *iceberg.IcebergService) *IcebergHandler {
 func (h *IcebergHandler) GetOverviewData(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -37,7 +38,7 @@ func (h *IcebergHandler) GetOverviewData(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewData(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewData(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -55,7 +56,8 @@ func (h *IcebergHandler) GetOverviewData(ctx *gin.Context) {
 func (h *IcebergHandler) GetOverviewStats(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -70,7 +72,7 @@ func (h *IcebergHandler) GetOverviewStats(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewStats(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewStats(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -88,7 +90,8 @@ func (h *IcebergHandler) GetOverviewStats(ctx *gin.Context) {
 func (h *IcebergHandler) GetOverviewSchema(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -103,7 +106,7 @@ func (h *IcebergHandler) GetOverviewSchema(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewSchema(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewSchema(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -121,7 +124,8 @@ func (h *IcebergHandler) GetOverviewSchema(ctx *gin.Context) {
 func (h *IcebergHandler) GetOverviewPartition(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -136,7 +140,7 @@ func (h *IcebergHandler) GetOverviewPartition(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewPartition(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewPartition(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -154,7 +158,8 @@ func (h *IcebergHandler) GetOverviewPartition(ctx *gin.Context) {
 func (h *IcebergHandler) GetOverviewSnapshot(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -169,7 +174,7 @@ func (h *IcebergHandler) GetOverviewSnapshot(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewSnapshot(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewSnapshot(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -187,7 +192,8 @@ func (h *IcebergHandler) GetOverviewSnapshot(ctx *gin.Context) {
 func (h *IcebergHandler) GetOverviewGraphs(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -202,7 +208,7 @@ func (h *IcebergHandler) GetOverviewGraphs(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetOverviewGraphs(ctx, userID, locid)
+	response, errf := h.Iceberg.GetOverviewGraphs(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -222,7 +228,8 @@ func (h *IcebergHandler) GetOverviewGraphs(ctx *gin.Context) {
 func (h *IcebergHandler) GetSchemasList(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -237,7 +244,7 @@ func (h *IcebergHandler) GetSchemasList(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetSchemasList(ctx, userID, locid)
+	response, errf := h.Iceberg.GetSchemasList(ctx, userID, locid, tableid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -255,7 +262,8 @@ func (h *IcebergHandler) GetSchemasList(ctx *gin.Context) {
 func (h *IcebergHandler) GetSchema(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -265,7 +273,7 @@ func (h *IcebergHandler) GetSchema(ctx *gin.Context) {
 	}
 
 	schemaid := ctx.Param("schemaid")
-	if locid == "" {
+	if schemaid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -280,7 +288,52 @@ func (h *IcebergHandler) GetSchema(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetSchema(ctx, userID, locid, schemaid)
+	response, errf := h.Iceberg.GetSchema(ctx, userID, locid, tableid, schemaid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *IcebergHandler) GetSchemaDiff(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	from := ctx.Param("from")
+	to := ctx.Param("to")
+	if from == "" || to == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetSchemaDiff(ctx, userID, locid, tableid, from, to)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -298,7 +351,8 @@ func (h *IcebergHandler) GetSchema(ctx *gin.Context) {
 func (h *IcebergHandler) GetSchemaData(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -308,7 +362,7 @@ func (h *IcebergHandler) GetSchemaData(ctx *gin.Context) {
 	}
 
 	schemaid := ctx.Param("schemaid")
-	if locid == "" {
+	if schemaid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -323,7 +377,7 @@ func (h *IcebergHandler) GetSchemaData(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetSchemaData(ctx, userID, locid, schemaid)
+	response, errf := h.Iceberg.GetSchemaData(ctx, userID, locid, tableid, schemaid)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -342,7 +396,8 @@ func (h *IcebergHandler) GetSchemaData(ctx *gin.Context) {
 func (h *IcebergHandler) GetSchemaColSizes(ctx *gin.Context) {
 
 	locid := ctx.Param("locid")
-	if locid == "" {
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -352,7 +407,332 @@ func (h *IcebergHandler) GetSchemaColSizes(ctx *gin.Context) {
 	}
 
 	schemaid := ctx.Param("schemaid")
-	if locid == "" {
+	if schemaid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetSchemaColSizes(ctx, userID, locid, tableid, schemaid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetHistory(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetHistory(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *IcebergHandler) GetSnapshotDiff(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	from := ctx.Param("from")
+	to := ctx.Param("to")
+	if from == "" || to == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetSnapshotDiff(ctx, userID, locid, tableid, from, to)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetRefs(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetRefs(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (h *IcebergHandler) GetRefOverview(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	ref := ctx.Param("ref")
+	if ref == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetRefOverview(ctx, userID, locid, tableid, ref)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetDeleteReport(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetDeleteReport(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetPartitions(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	offset := ctx.Param("offset")
+	if offset == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetPartitions(ctx, userID, locid, tableid, offset)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetLayoutTimeline(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Iceberg.GetLayoutTimeline(ctx, userID, locid, tableid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+func (h *IcebergHandler) GetOrphans(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	tableid := ctx.Param("tableid")
+	if locid == "" || tableid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	offset := ctx.Param("offset")
+	if offset == "" {
 		ctx.JSON(http.StatusBadRequest, errs.Errorf{
 			Type:      errs.ErrMissingField,
 			Message:   "Missing url params.",
@@ -367,7 +747,7 @@ func (h *IcebergHandler) GetSchemaColSizes(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Iceberg.GetSchemaColSizes(ctx, userID, locid, schemaid)
+	response, errf := h.Iceberg.GetOrphans(ctx, userID, locid, tableid, offset)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
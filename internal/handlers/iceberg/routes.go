@@ -8,19 +8,46 @@ import (
 
 func (h *IcebergHandler) RegisterRoutes(routegrp *gin.RouterGroup) {
 
-	routegrp.GET("/overview/data/:locid", h.GetOverviewData)
-	routegrp.GET("/overview/stats/:locid", h.GetOverviewStats)
-	routegrp.GET("/overview/schema/:locid", h.GetOverviewSchema)
-	routegrp.GET("/overview/partition/:locid", h.GetOverviewPartition)
-	routegrp.GET("/overview/snapshot/:locid", h.GetOverviewSnapshot)
-	routegrp.GET("/overview/graphs/:locid", h.GetOverviewGraphs)
+	routegrp.GET("/overview/data/:locid/:tableid", h.GetOverviewData)
+	routegrp.GET("/overview/stats/:locid/:tableid", h.GetOverviewStats)
+	routegrp.GET("/overview/schema/:locid/:tableid", h.GetOverviewSchema)
+	routegrp.GET("/overview/partition/:locid/:tableid", h.GetOverviewPartition)
+	routegrp.GET("/overview/snapshot/:locid/:tableid", h.GetOverviewSnapshot)
+	routegrp.GET("/overview/graphs/:locid/:tableid", h.GetOverviewGraphs)
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 
-	routegrp.GET("/schema/compare/list/:locid", h.GetSchemasList)
-	routegrp.GET("/schema/compare/getschema/:locid/:schemaid", h.GetSchema)
-	routegrp.GET("/schema/data/:locid/:schemaid", h.GetSchemaData)
-	routegrp.GET("/schema/colsizes/:locid/:schemaid", h.GetSchemaColSizes)
+	routegrp.GET("/schema/compare/list/:locid/:tableid", h.GetSchemasList)
+	routegrp.GET("/schema/compare/getschema/:locid/:tableid/:schemaid", h.GetSchema)
+	routegrp.GET("/schema/compare/diff/:locid/:tableid/:from/:to", h.GetSchemaDiff)
+	routegrp.GET("/schema/data/:locid/:tableid/:schemaid", h.GetSchemaData)
+	routegrp.GET("/schema/colsizes/:locid/:tableid/:schemaid", h.GetSchemaColSizes)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/history/:locid/:tableid", h.GetHistory)
+	routegrp.GET("/diff/:locid/:tableid/:from/:to", h.GetSnapshotDiff)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/refs/:locid/:tableid", h.GetRefs)
+	routegrp.GET("/refs/overview/:locid/:tableid/:ref", h.GetRefOverview)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/deletes/:locid/:tableid", h.GetDeleteReport)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/partitions/:locid/:tableid/:offset", h.GetPartitions)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/layout/:locid/:tableid", h.GetLayoutTimeline)
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	routegrp.GET("/orphans/:locid/:tableid/:offset", h.GetOrphans)
 
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 
@@ -430,7 +430,18 @@ func (h *ManagerHandler) AnalyzeLake(ctx *gin.Context) {
 		return
 	}
 
-	response, errfs := h.Manager.AnalyzeLake(ctx, userID, lakeid)
+	opts := dto.ScanOptions{}
+	err := ctx.Bind(&opts)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrInvalidFormat,
+			Message:   "Invalid scan options in url query.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	response, errfs := h.Manager.AnalyzeLake(ctx, userID, lakeid, opts)
 	if len(errfs) != 0 {
 		errResp := make([]*errs.Errorf, 0)
 		for _, errf := range errfs {
@@ -466,7 +477,18 @@ func (h *ManagerHandler) AnalyzeLoc(ctx *gin.Context) {
 		return
 	}
 
-	response, errf := h.Manager.AnalyzeLoc(ctx, userID, locid)
+	opts := dto.ScanOptions{}
+	err := ctx.Bind(&opts)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrInvalidFormat,
+			Message:   "Invalid scan options in url query.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	response, errf := h.Manager.AnalyzeLoc(ctx, userID, locid, opts)
 	if errf != nil {
 		fmt.Println(errf.Message)
 		if errf.ReturnRaw {
@@ -511,3 +533,36 @@ func (h *ManagerHandler) FetchLocation(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+func (h *ManagerHandler) GetLocTables(ctx *gin.Context) {
+
+	locid := ctx.Param("locid")
+	if locid == "" {
+		ctx.JSON(http.StatusBadRequest, errs.Errorf{
+			Type:      errs.ErrMissingField,
+			Message:   "Missing url params.",
+			ReturnRaw: true,
+		})
+		return
+	}
+
+	userID, errf := h.getUserID(ctx)
+	if errf != nil {
+		ctx.JSON(http.StatusBadRequest, errf)
+		return
+	}
+
+	response, errf := h.Manager.GetLocTables(ctx, userID, locid)
+	if errf != nil {
+		fmt.Println(errf.Message)
+		if errf.ReturnRaw {
+			ctx.JSON(http.StatusBadRequest, errf)
+		} else {
+			ctx.Set("error", errf.Message)
+			ctx.Status(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
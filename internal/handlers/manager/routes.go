@@ -63,11 +63,14 @@ func (h *ManagerHandler) RegisterRoutes(routegrp *gin.RouterGroup) {
 	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 
 	// starts analyzing requested lake, lake should obv be already registered
+	// the query opts into the costlier scans, like ?IcebergDeepScan=true&IcebergOrphanScan=true
 	routegrp.GET("/analyze/:lakeid", h.AnalyzeLake)
 	// starts analyzing requested lake, lake should obv be already registered
 	routegrp.GET("/analyze/loc/:locid", h.AnalyzeLoc)
 	// returns the entire report of a location
 	routegrp.GET("/fetch/:lakeid/:locid", h.FetchLocation)
+	// lists the tables detected in a location, their ids address them in the format apis.
+	routegrp.GET("/tables/:locid", h.GetLocTables)
 }
 
 // extractUserID extracts the user ID and other required parameters from the context with explicit type assertion.
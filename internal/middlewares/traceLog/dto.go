@@ -19,10 +19,10 @@ var RoutesList = map[string]RouteInfo{
 		Action:     "new_lake",
 		BodyFields: []string{"new_lake_name"},
 	},
-	"/lens/iceberg/overview/data/:locid": {
+	"/lens/iceberg/overview/data/:locid/:tableid": {
 		ActionID: 1002,
 		Action:   "overview_data",
-		Params:   []string{"locid"},
+		Params:   []string{"locid", "tableid"},
 	},
 	"/lens/manager/analyze/loc/:locid": {
 		ActionID: 1003,
@@ -0,0 +1,1078 @@
+package delta
+
+import (
+	"cmp"
+	"fmt"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/delta"
+	sqlc "lakelens/internal/sqlc/generate"
+	"lakelens/internal/stash"
+	deltautils "lakelens/internal/utils/delta"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type DeltaService struct {
+	Queries     *sqlc.Queries
+	RedisClient *redis.Client
+	DB          *pgxpool.Pool
+
+	Stash *stash.StashService
+}
+
+func NewDeltaService(queries *sqlc.Queries, redis *redis.Client, db *pgxpool.Pool, stash *stash.StashService) *DeltaService {
+	return &DeltaService{
+		Queries:     queries,
+		RedisClient: redis,
+		DB:          db,
+
+		Stash: stash,
+	}
+}
+
+// fetchTable returns the cached delta table {tableid} of location {locid}.
+func (s *DeltaService) fetchTable(ctx *gin.Context, userID int64, locid, tableid string) (*dto.Table, *errs.Errorf) {
+
+	locID, err := strconv.ParseInt(locid, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse location id to int64 : " + err.Error(),
+		}
+	}
+
+	locData, err := s.Queries.GetLocationData(ctx, locID)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get location data : " + err.Error(),
+		}
+	}
+
+	if locData.UserID != userID {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrUnauthorized,
+			Message:   "Requested resource does not belong to you.",
+			ReturnRaw: true,
+		}
+	}
+
+	lakeData, err := s.Queries.GetLakeData(ctx, locData.LakeID)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get lake data : " + err.Error(),
+		}
+	}
+
+	cache := new(stash.CacheMetadata)
+	var exists bool
+	cacheKey := dto.CacheKey(locData.LakeID, locData.LocID)
+
+	switch lakeData.Ptype {
+	case consts.AWSS3:
+		cache, exists = s.Stash.GetBucketS3(cacheKey)
+	case consts.MinIO:
+		cache, exists = s.Stash.GetBucketMinIO(cacheKey)
+	case consts.Azure:
+		cache, exists = s.Stash.GetBucketAzure(cacheKey)
+	case consts.GCS:
+		cache, exists = s.Stash.GetBucketGCS(cacheKey)
+	case consts.Local:
+		cache, exists = s.Stash.GetBucketLocal(cacheKey)
+
+	default:
+		// ?
+	}
+
+	if !exists {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Requested resource not found. Please rescan to fetch data.",
+			ReturnRaw: true,
+		}
+	}
+
+	table := cache.Bucket.GetTable(tableid)
+	if table == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Requested table not found in the location. Please rescan to fetch data.",
+			ReturnRaw: true,
+		}
+	}
+
+	if table.TableType != consts.DeltaTable {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrResourceLocked,
+			Message:   "Requested resource is not of expected table type (delta).",
+			ReturnRaw: true,
+		}
+	}
+
+	if table.Delta.Snapshot == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "The state of the requested delta table could not be reconstructed, see the table errors.",
+			ReturnRaw: true,
+		}
+	}
+
+	return table, nil
+}
+
+// fieldID returns the column mapping id of the field if set, else its 1 based position in the schema.
+func fieldID(pos int, field formats.DeltaSchemaField) int64 {
+
+	if meta, ok := field.Metadata.(map[string]any); ok {
+		if id, ok := meta["delta.columnMapping.id"].(float64); ok {
+			return int64(id)
+		}
+	}
+
+	return int64(pos + 1)
+}
+
+// schemaLogs returns the commits (and checkpoint) that introduced each schema read, oldest first.
+//
+// Metadata changes that keep the schema (like table properties) do not introduce a new schema.
+func schemaLogs(table *dto.Table) []*formats.DeltaLog {
+
+	logs := make([]*formats.DeltaLog, 0, len(table.Delta.Log)+1)
+	for i := len(table.Delta.Log) - 1; i >= 0; i-- {
+		logs = append(logs, table.Delta.Log[i])
+	}
+	if cp := table.Delta.Checkpoint; cp != nil {
+		pos, _ := slices.BinarySearchFunc(logs, cp.Version, func(log *formats.DeltaLog, v int64) int { return cmp.Compare(log.Version, v) })
+		logs = slices.Insert(logs, pos, cp.Log)
+	}
+
+	schemas := make([]*formats.DeltaLog, 0)
+	var prevSchema string
+	for _, log := range logs {
+		if log.Metadata.SchemaString == "" || log.Metadata.SchemaString == prevSchema {
+			continue
+		}
+		schemas = append(schemas, log)
+		prevSchema = log.Metadata.SchemaString
+	}
+
+	return schemas
+}
+
+// currentSchemaID returns the version that introduced the schema of the snapshot.
+func currentSchemaID(table *dto.Table) int64 {
+
+	schemas := schemaLogs(table)
+	if len(schemas) == 0 {
+		return table.Delta.Snapshot.Version
+	}
+
+	return schemas[len(schemas)-1].Version
+}
+
+func (s *DeltaService) GetOverviewData(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewData, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	filesRead := map[string]int64{
+		"Commit Files": int64(len(table.Delta.Log)),
+	}
+
+	fileURIs := make(map[string]string, 0)
+	if len(table.Delta.Log) > 0 {
+		fileURIs["Latest Commit File"] = table.Delta.Log[0].Key
+	}
+	if cp := table.Delta.Checkpoint; cp != nil {
+		fileURIs["Checkpoint File"] = cp.Keys[0]
+		filesRead["Checkpoint Files"] = int64(len(cp.Keys))
+	}
+
+	return &dto.OverviewData{
+		FoundAt:     table.Delta.URI,
+		Location:    table.URI,
+		TableUUID:   table.Delta.Snapshot.Metadata.ID,
+		FilesReadMp: filesRead,
+		TableType:   table.TableType,
+		FileURIs:    fileURIs,
+	}, nil
+}
+
+func (s *DeltaService) GetOverviewStats(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewStats, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	snap := table.Delta.Snapshot
+	protocol := snap.Protocol
+
+	totDataFiles := int64(len(snap.Files))
+	var avgFileSize int64
+	if totDataFiles > 0 {
+		avgFileSize = snap.SizeInBytes / totDataFiles
+	}
+
+	return &dto.OverviewStats{
+		Table: dto.OverviewStatsTable{
+			TableType:    table.TableType,
+			TableVersion: protocol.MinWriterVersion,
+			TableSpecs:   fmt.Sprintf("minReaderVersion=%d, minWriterVersion=%d", protocol.MinReaderVersion, protocol.MinWriterVersion),
+		},
+		Rows: dto.OverviewStatsRowCount{
+			TotalCount: strconv.FormatInt(snap.NumRecords, 10),
+			DeltaCount: snap.RecordsDelta,
+		},
+		Version: dto.OverviewStatsVersion{
+			CurrentVersion: strconv.FormatInt(snap.Version, 10),
+			LastSnapshot:   snap.Timestamp,
+			TotalSnapshots: snap.Version + 1,
+		},
+		Storage: dto.OverviewStatsStorage{
+			TotalSize:      snap.SizeInBytes,
+			TotalDataFiles: totDataFiles,
+			AvgFileSize:    avgFileSize,
+		},
+	}, nil
+}
+
+func (s *DeltaService) GetOverviewSchema(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewSchema, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	fields := make([]*dto.OverviewSchemaField, 0)
+	for i, field := range table.Delta.Snapshot.Metadata.Schema.Fields {
+		fields = append(fields, &dto.OverviewSchemaField{
+			ID:       fieldID(i, field),
+			Name:     field.Name,
+			Type:     deltautils.TypeString(field.Type),
+			Required: !field.Nullable,
+		})
+	}
+
+	return &dto.OverviewSchema{
+		SchemaID: currentSchemaID(table),
+		Fields:   fields,
+	}, nil
+}
+
+func (s *DeltaService) GetOverviewPartition(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewPartition, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	meta := table.Delta.Snapshot.Metadata
+
+	// delta only partitions by the identity of whole columns.
+	fields := make([]*dto.OverviewPartitionField, 0)
+	for i, col := range meta.PartitionColumns {
+		name := fmt.Sprintf("%v", col)
+
+		var sourceID int64
+		for pos, field := range meta.Schema.Fields {
+			if field.Name == name {
+				sourceID = fieldID(pos, field)
+				break
+			}
+		}
+
+		fields = append(fields, &dto.OverviewPartitionField{
+			Name:      name,
+			Transform: "identity",
+			FieldID:   int64(i),
+			SourceID:  sourceID,
+		})
+	}
+
+	return &dto.OverviewPartition{
+		DefaultSpecID: currentSchemaID(table),
+		Fields:        fields,
+	}, nil
+}
+
+// GetOverviewGraphs replays the commits after the checkpoint, the table state before it is not known.
+func (s *DeltaService) GetOverviewGraphs(ctx *gin.Context, userID int64, locid, tableid string) ([]*dto.OverviewGraphs, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	snap := deltautils.NewSnapshot()
+	if cp := table.Delta.Checkpoint; cp != nil {
+		deltautils.ApplyLog(snap, cp.Log)
+	}
+	base := snap.Version
+
+	resp := make([]*dto.OverviewGraphs, 0)
+	for i := len(table.Delta.Log) - 1; i >= 0; i-- {
+		log := table.Delta.Log[i]
+		if log.Version <= base {
+			continue
+		}
+
+		deltautils.ApplyLog(snap, log)
+		resp = append(resp, &dto.OverviewGraphs{
+			TimeStampMS:      log.CommitInfo.Timestamp,
+			TotalRecords:     strconv.FormatInt(snap.NumRecords, 10),
+			TotalFileSize:    strconv.FormatInt(snap.SizeInBytes, 10),
+			TotalDataFiles:   strconv.Itoa(len(snap.Files)),
+			TotalDeleteFiles: "0",
+		})
+	}
+
+	return resp, nil
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+// GetSchemasList lists the schemas read from the log, each identified by the version that introduced it.
+func (s *DeltaService) GetSchemasList(ctx *gin.Context, userID int64, locid, tableid string) (*dto.SchemaList, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	abc := make(map[int64]*dto.SchemaListData, 0)
+
+	schemas := schemaLogs(table)
+	for i, log := range schemas {
+
+		fromTS := log.CommitInfo.Timestamp
+		if fromTS == 0 {
+			fromTS = log.Metadata.CreatedTime
+		}
+
+		validUpto := table.Delta.Snapshot.Version
+		if i+1 < len(schemas) {
+			validUpto = schemas[i+1].Version - 1
+		}
+
+		abc[log.Version] = &dto.SchemaListData{
+			SchemaID:            log.Version,
+			FromTimeStampMS:     fromTS,
+			ValidUptoSnapshotID: strconv.FormatInt(validUpto, 10),
+		}
+	}
+
+	return &dto.SchemaList{
+		List: abc,
+	}, nil
+}
+
+// findSchema returns the log that introduced the schema of the given version, or the current one for "latest".
+func findSchema(table *dto.Table, schemaid string) (*formats.DeltaLog, *errs.Errorf) {
+
+	schemas := schemaLogs(table)
+
+	if schemaid == "latest" {
+		if len(schemas) == 0 {
+			return &formats.DeltaLog{
+				Version:  table.Delta.Snapshot.Version,
+				Metadata: table.Delta.Snapshot.Metadata,
+			}, nil
+		}
+		return schemas[len(schemas)-1], nil
+	}
+
+	schemaID, err := strconv.ParseInt(schemaid, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse schema id to int64 : " + err.Error(),
+		}
+	}
+
+	for _, log := range schemas {
+		if log.Version == schemaID {
+			return log, nil
+		}
+	}
+
+	return nil, &errs.Errorf{
+		Type:      errs.ErrNotFound,
+		Message:   "Requested schema not found in the commits read.",
+		ReturnRaw: true,
+	}
+}
+
+func (s *DeltaService) GetSchema(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.Schema, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	log, errf := findSchema(table, schemaid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	fields := make([]*dto.SchemaField, 0)
+	for i, field := range log.Metadata.Schema.Fields {
+		fields = append(fields, &dto.SchemaField{
+			ID:       fieldID(i, field),
+			Name:     field.Name,
+			Type:     deltautils.TypeString(field.Type),
+			Required: !field.Nullable,
+		})
+	}
+
+	return &dto.Schema{
+		SchemaID: log.Version,
+		Fields:   fields,
+	}, nil
+}
+
+// GetSchemaColSizes aggregates the per file stats of the live files for the columns of the given schema.
+//
+// Delta does not record column sizes, so only the null and value counts are set.
+func (s *DeltaService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.SchemaColSizes, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	log, errf := findSchema(table, schemaid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	nullsCountMap := make(map[string]int64)
+	valsCountMap := make(map[string]int64)
+
+	for _, file := range table.Delta.Snapshot.Files {
+		stats, errf := deltautils.ParseStats(file.Add.Stats)
+		if errf != nil {
+			return nil, errf
+		}
+		if stats == nil {
+			continue
+		}
+
+		for col, count := range stats.NullCount {
+			// nested columns have a struct of counts, they are not aggregated here.
+			nulls, ok := count.(float64)
+			if !ok {
+				continue
+			}
+			nullsCountMap[col] += int64(nulls)
+			valsCountMap[col] += stats.NumRecords
+		}
+	}
+
+	result := make([]dto.ColSize, 0)
+	for i, f := range log.Metadata.Schema.Fields {
+		// the stats are kept under the physical names with column mapping.
+		key := deltautils.PhysicalName(f)
+		vals, ok := valsCountMap[key]
+		if !ok {
+			continue
+		}
+		result = append(result, dto.ColSize{
+			ID:         fieldID(i, f),
+			Name:       f.Name,
+			NullCount:  nullsCountMap[key],
+			ValueCount: vals,
+		})
+	}
+
+	slices.SortFunc(result, func(a dto.ColSize, b dto.ColSize) int { return int(a.ID) - int(b.ID) })
+
+	return &dto.SchemaColSizes{
+		SchemaID: log.Version,
+		ColSizes: result,
+	}, nil
+}
+
+// GetSchemaColStats aggregates the typed per file stats of the live files for the (leaf) columns of the given schema.
+func (s *DeltaService) GetSchemaColStats(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.DeltaSchemaColStats, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	log, errf := findSchema(table, schemaid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	snap := table.Delta.Snapshot
+	resp := &dto.DeltaSchemaColStats{
+		SchemaID:   log.Version,
+		NumRecords: snap.NumRecords,
+		Columns:    make([]*dto.DeltaColStats, 0),
+	}
+
+	columns := make(map[string]*dto.DeltaColStats)
+	rows := make([]int64, 0, len(snap.Files))
+
+	for _, file := range snap.Files {
+		resp.FileRows.Files++
+
+		stats, errf := deltautils.ParseFileStats(file.Add.Stats, log.Metadata.Schema)
+		if errf != nil {
+			return nil, errf
+		}
+		if stats == nil {
+			resp.FileRows.FilesNoStats++
+			continue
+		}
+		rows = append(rows, stats.NumRecords)
+
+		for path, colStats := range stats.Columns {
+			col, ok := columns[path]
+			if !ok {
+				col = &dto.DeltaColStats{
+					Path: path,
+					Type: colStats.Type,
+				}
+				columns[path] = col
+			}
+
+			col.FilesWithStats++
+			if stats.TightBounds != nil && !*stats.TightBounds {
+				col.WideBounds++
+			}
+			if colStats.HasNullCount {
+				col.NullCount += colStats.NullCount
+				col.ValueCount += stats.NumRecords
+			}
+			if colStats.Min != nil && (col.Min == nil || deltautils.CompareValues(col.Type, colStats.Min, col.Min) < 0) {
+				col.Min = colStats.Min
+			}
+			if colStats.Max != nil && (col.Max == nil || deltautils.CompareValues(col.Type, colStats.Max, col.Max) > 0) {
+				col.Max = colStats.Max
+			}
+		}
+	}
+
+	if len(rows) > 0 {
+		slices.Sort(rows)
+
+		var total int64
+		for _, r := range rows {
+			total += r
+		}
+
+		resp.FileRows.MinRows = rows[0]
+		resp.FileRows.MaxRows = rows[len(rows)-1]
+		resp.FileRows.AvgRows = total / int64(len(rows))
+		resp.FileRows.MedianRows = rows[len(rows)/2]
+	}
+
+	for _, col := range columns {
+		if col.ValueCount > 0 {
+			col.NullRatio = float64(col.NullCount) / float64(col.ValueCount)
+		}
+		resp.Columns = append(resp.Columns, col)
+	}
+
+	slices.SortFunc(resp.Columns, func(a, b *dto.DeltaColStats) int { return strings.Compare(a.Path, b.Path) })
+
+	return resp, nil
+}
+
+// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+// earliestVersion returns the oldest version the checkpoint and commits read can reconstruct.
+func earliestVersion(table *dto.Table) int64 {
+
+	read := make(map[int64]bool, len(table.Delta.Log))
+	for _, log := range table.Delta.Log {
+		read[log.Version] = true
+	}
+	if read[0] {
+		return 0
+	}
+
+	if cp := table.Delta.Checkpoint; cp != nil {
+		return cp.Version
+	}
+
+	return table.Delta.Snapshot.Version
+}
+
+// stateAt reconstructs the table at the given version, replaying the commits read from the checkpoint
+// (or version 0) up to it.
+func stateAt(table *dto.Table, version int64) (*formats.DeltaSnapshot, *errs.Errorf) {
+
+	latest := table.Delta.Snapshot.Version
+	if version < 0 || version > latest {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrOutOfRange,
+			Message:   fmt.Sprintf("Requested version %d does not exist, the latest version is %d.", version, latest),
+			ReturnRaw: true,
+		}
+	}
+
+	logs := make(map[int64]*formats.DeltaLog, len(table.Delta.Log))
+	for _, log := range table.Delta.Log {
+		logs[log.Version] = log
+	}
+
+	snap := deltautils.NewSnapshot()
+	if cp := table.Delta.Checkpoint; cp != nil && cp.Version <= version {
+		deltautils.ApplyLog(snap, cp.Log)
+		if log, ok := logs[cp.Version]; ok {
+			snap.Timestamp = deltautils.CommitTimestamp(log)
+		}
+	}
+
+	for v := snap.Version + 1; v <= version; v++ {
+		log, ok := logs[v]
+		if !ok {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrOutOfRange,
+				Message:   fmt.Sprintf("Requested version %d can not be reconstructed from the scanned log, the commit %d was not read. The earliest version is %d.", version, v, earliestVersion(table)),
+				ReturnRaw: true,
+			}
+		}
+		deltautils.ApplyLog(snap, log)
+	}
+
+	return snap, nil
+}
+
+func (s *DeltaService) versionState(table *dto.Table, version int64) (*dto.DeltaVersionState, *errs.Errorf) {
+
+	snap, errf := stateAt(table, version)
+	if errf != nil {
+		return nil, errf
+	}
+
+	resp := &dto.DeltaVersionState{
+		Version:         snap.Version,
+		Timestamp:       snap.Timestamp,
+		EarliestVersion: earliestVersion(table),
+		LatestVersion:   table.Delta.Snapshot.Version,
+
+		Protocol: dto.DeltaProtocolResp{
+			MinReaderVersion: snap.Protocol.MinReaderVersion,
+			MinWriterVersion: snap.Protocol.MinWriterVersion,
+			ReaderFeatures:   snap.Protocol.ReaderFeatures,
+			WriterFeatures:   snap.Protocol.WriterFeatures,
+		},
+		Schema:           make([]*dto.SchemaField, 0),
+		PartitionColumns: make([]string, 0),
+		Configuration:    snap.Metadata.Configuration,
+
+		NumFiles:     int64(len(snap.Files)),
+		SizeInBytes:  snap.SizeInBytes,
+		NumRecords:   snap.NumRecords,
+		FilesNoStats: snap.NoStats,
+	}
+
+	for _, log := range table.Delta.Log {
+		if log.Version == snap.Version {
+			resp.Operation = log.CommitInfo.Operation
+			break
+		}
+	}
+
+	for i, field := range snap.Metadata.Schema.Fields {
+		resp.Schema = append(resp.Schema, &dto.SchemaField{
+			ID:       fieldID(i, field),
+			Name:     field.Name,
+			Type:     deltautils.TypeString(field.Type),
+			Required: !field.Nullable,
+		})
+	}
+
+	for _, col := range snap.Metadata.PartitionColumns {
+		resp.PartitionColumns = append(resp.PartitionColumns, fmt.Sprintf("%v", col))
+	}
+
+	return resp, nil
+}
+
+// GetVersion returns the state of the table as of the given version.
+func (s *DeltaService) GetVersion(ctx *gin.Context, userID int64, locid, tableid, version string) (*dto.DeltaVersionState, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	ver, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse version to int64 : " + err.Error(),
+		}
+	}
+
+	return s.versionState(table, ver)
+}
+
+// GetTimestamp returns the state of the table as of the given timestamp (epoch ms or RFC 3339), that is the
+// latest version committed at or before it.
+func (s *DeltaService) GetTimestamp(ctx *gin.Context, userID int64, locid, tableid, timestamp string) (*dto.DeltaVersionState, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrInvalidInput,
+				Message: "Timestamp should be epoch milliseconds or RFC 3339 : " + err.Error(),
+			}
+		}
+		ts = t.UnixMilli()
+	}
+
+	// the log is newest first.
+	for _, log := range table.Delta.Log {
+		if commitTS := deltautils.CommitTimestamp(log); commitTS != 0 && commitTS <= ts {
+			return s.versionState(table, log.Version)
+		}
+	}
+
+	return nil, &errs.Errorf{
+		Type:      errs.ErrOutOfRange,
+		Message:   "Requested timestamp is before the oldest commit read from the log.",
+		ReturnRaw: true,
+	}
+}
+
+// GetDeletionVectors returns the deletion vectors of the live files, with the rows they delete as per their
+// descriptors and as per the bitmaps read during the scan.
+func (s *DeltaService) GetDeletionVectors(ctx *gin.Context, userID int64, locid, tableid string) (*dto.DeltaDeletionVectors, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	snap := table.Delta.Snapshot
+	resp := &dto.DeltaDeletionVectors{
+		Enabled:   slices.Contains(snap.Protocol.ReaderFeatures, "deletionVectors"),
+		Version:   snap.Version,
+		LiveFiles: int64(len(snap.Files)),
+		Files:     make([]*dto.DeltaDVFile, 0),
+	}
+
+	for filePath, file := range snap.Files {
+		dv := file.Add.DeletionVector
+		if dv == nil {
+			continue
+		}
+
+		dvFile := &dto.DeltaDVFile{
+			Path:        filePath,
+			StorageType: dv.StorageType,
+			SizeInBytes: dv.SizeInBytes,
+			NumRecords:  file.NumRecords,
+			DeletedRows: dv.Cardinality,
+			ReadRows:    -1,
+			Error:       table.Delta.DVErrors[filePath],
+		}
+
+		resp.FilesWithDVs++
+		resp.DeletedRows += dv.Cardinality
+		if dv.StorageType == deltautils.DVStorageInline {
+			resp.InlineDVs++
+		}
+
+		if rows, ok := table.Delta.DVRows[filePath]; ok {
+			dvFile.ReadRows = rows
+			resp.ReadRows += rows
+			if rows != dv.Cardinality {
+				resp.Mismatches++
+			}
+		} else if dvFile.Error == "" {
+			resp.FilesNotRead++
+		}
+
+		resp.Files = append(resp.Files, dvFile)
+	}
+
+	slices.SortFunc(resp.Files, func(a, b *dto.DeltaDVFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return resp, nil
+}
+
+// GetProtocol returns the features the protocol of the table requires and the Delta Lake (Spark) releases
+// able to read it. The engine support is hand maintained, see deltautils.Features.
+func (s *DeltaService) GetProtocol(ctx *gin.Context, userID int64, locid, tableid string) (*dto.DeltaProtocolAudit, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	protocol := table.Delta.Snapshot.Protocol
+	minRelease, unknown := deltautils.MinDeltaSparkReader(protocol)
+
+	resp := &dto.DeltaProtocolAudit{
+		Protocol: dto.DeltaProtocolResp{
+			MinReaderVersion: protocol.MinReaderVersion,
+			MinWriterVersion: protocol.MinWriterVersion,
+			ReaderFeatures:   protocol.ReaderFeatures,
+			WriterFeatures:   protocol.WriterFeatures,
+		},
+		TableFeatures:     protocol.MinReaderVersion >= 3 || protocol.MinWriterVersion >= 7,
+		Features:          make([]*dto.DeltaFeature, 0),
+		UnknownFeatures:   unknown,
+		MinDeltaSparkRead: minRelease,
+		Engines:           make([]*dto.DeltaEngineSupport, 0, len(deltautils.DeltaSparkReleases)),
+	}
+
+	for _, name := range deltautils.RequiredFeatures(protocol) {
+		feature, known := deltautils.Features[name]
+
+		resp.Features = append(resp.Features, &dto.DeltaFeature{
+			Name:             name,
+			Reader:           feature.Reader || slices.Contains(protocol.ReaderFeatures, name),
+			Legacy:           !slices.Contains(protocol.ReaderFeatures, name) && !slices.Contains(protocol.WriterFeatures, name),
+			Known:            known,
+			Description:      feature.Description,
+			DeltaSparkReader: feature.DeltaSparkReader,
+		})
+	}
+
+	for _, release := range deltautils.DeltaSparkReleases {
+		resp.Engines = append(resp.Engines, &dto.DeltaEngineSupport{
+			Engine:  "Delta Lake (Spark)",
+			Release: release,
+			CanRead: len(unknown) == 0 && deltautils.CompareReleases(release, minRelease) >= 0,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetChangeFeed returns the changes of every commit read, from its change data files if it wrote any,
+// else derived from the data files it added and removed.
+func (s *DeltaService) GetChangeFeed(ctx *gin.Context, userID int64, locid, tableid string) (*dto.DeltaChangeFeed, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	resp := &dto.DeltaChangeFeed{
+		Enabled:         table.Delta.Snapshot.Metadata.Configuration["delta.enableChangeDataFeed"] == "true",
+		EarliestVersion: earliestVersion(table),
+		LatestVersion:   table.Delta.Snapshot.Version,
+		Versions:        make([]*dto.DeltaVersionChanges, 0, len(table.Delta.Log)),
+	}
+
+	for _, log := range table.Delta.Log {
+		changes := &dto.DeltaVersionChanges{
+			Version:   log.Version,
+			Timestamp: deltautils.CommitTimestamp(log),
+			Operation: log.CommitInfo.Operation,
+			Source:    "none",
+		}
+
+		// the change data files hold every change of the commit, the data files are not part of the feed then.
+		if len(log.CDC) > 0 {
+			changes.Source = "cdc"
+
+			counts := map[string]*dto.DeltaChangeCount{
+				deltautils.ChangeInsert:          &changes.Insert,
+				deltautils.ChangeUpdatePreimage:  &changes.UpdatePreimage,
+				deltautils.ChangeUpdatePostimage: &changes.UpdatePostimage,
+				deltautils.ChangeDelete:          &changes.Delete,
+			}
+
+			for _, cdc := range log.CDC {
+				changes.CDCFiles++
+				changes.CDCBytes += cdc.Size
+
+				cdcFile, ok := table.Delta.CDCFiles[cdc.Path]
+				if !ok {
+					changes.CDCFilesNotRead++
+					continue
+				}
+				addCDCFile(counts, cdc.Size, cdcFile.ChangeTypes)
+			}
+
+			resp.Versions = append(resp.Versions, changes)
+			continue
+		}
+
+		for _, add := range log.Add {
+			if add.DataChange {
+				changes.Source = "add/remove"
+				addChange(&changes.Insert, add.Size, add.Stats)
+			}
+		}
+		for _, rm := range log.Remove {
+			if rm.DataChange {
+				changes.Source = "add/remove"
+				addChange(&changes.Delete, rm.Size, rm.Stats)
+			}
+		}
+
+		resp.Versions = append(resp.Versions, changes)
+	}
+
+	return resp, nil
+}
+
+// cdcChangeTypes are the change types of the feed, in the order a change data file is counted under on ties.
+var cdcChangeTypes = []string{
+	deltautils.ChangeInsert,
+	deltautils.ChangeUpdatePreimage,
+	deltautils.ChangeUpdatePostimage,
+	deltautils.ChangeDelete,
+}
+
+// addCDCFile counts a change data file, given its rows per change type.
+//
+// The file is counted once, under the type with the most rows, and its size is split between the types by their
+// share of the rows. So the per type files and bytes add up to the change data files read, bar the empty ones.
+func addCDCFile(counts map[string]*dto.DeltaChangeCount, size int64, changeTypes map[string]int64) {
+
+	var total int64
+	top := ""
+	for _, changeType := range cdcChangeTypes {
+		rows := changeTypes[changeType]
+		total += rows
+		if top == "" || rows > changeTypes[top] {
+			top = changeType
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	split := int64(0)
+	for _, changeType := range cdcChangeTypes {
+		rows := changeTypes[changeType]
+		if rows == 0 {
+			continue
+		}
+		bytes := size * rows / total
+		counts[changeType].Bytes += bytes
+		counts[changeType].Rows += rows
+		split += bytes
+	}
+
+	// the bytes lost to the integer division.
+	counts[top].Files++
+	counts[top].Bytes += size - split
+}
+
+// addChange counts a data file added or removed, its rows become unknown without stats.
+func addChange(count *dto.DeltaChangeCount, size int64, rawStats string) {
+
+	count.Files++
+	count.Bytes += size
+
+	if count.Rows < 0 {
+		return
+	}
+	stats, errf := deltautils.ParseStats(rawStats)
+	if errf != nil || stats == nil {
+		count.Rows = -1
+		return
+	}
+	count.Rows += stats.NumRecords
+}
+
+// GetChanges returns the first rows of the change data files of the versions from {from} to {to}, both
+// included. Only the change data files read during the scan are previewed.
+func (s *DeltaService) GetChanges(ctx *gin.Context, userID int64, locid, tableid, from, to string) (*dto.DeltaChangeRows, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	fromVer, err := strconv.ParseInt(from, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse from version to int64 : " + err.Error(),
+		}
+	}
+	toVer, err := strconv.ParseInt(to, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse to version to int64 : " + err.Error(),
+		}
+	}
+
+	if fromVer > toVer {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrOutOfRange,
+			Message:   fmt.Sprintf("The from version %d is after the to version %d.", fromVer, toVer),
+			ReturnRaw: true,
+		}
+	}
+
+	resp := &dto.DeltaChangeRows{
+		From:          fromVer,
+		To:            toVer,
+		Rows:          make([]map[string]any, 0),
+		FilesNotRead:  make([]string, 0),
+		VersionsNoCDC: make([]int64, 0),
+	}
+
+	// the log is newest first, the rows are returned in commit order.
+	for i := len(table.Delta.Log) - 1; i >= 0; i-- {
+		log := table.Delta.Log[i]
+		if log.Version < fromVer || log.Version > toVer {
+			continue
+		}
+
+		if len(log.CDC) == 0 {
+			resp.VersionsNoCDC = append(resp.VersionsNoCDC, log.Version)
+			continue
+		}
+
+		for _, cdc := range log.CDC {
+			cdcFile, ok := table.Delta.CDCFiles[cdc.Path]
+			if !ok {
+				resp.FilesNotRead = append(resp.FilesNotRead, cdc.Path)
+				continue
+			}
+			if int64(len(cdcFile.Rows)) < cdcFile.NumRows {
+				resp.Truncated = true
+			}
+
+			for _, row := range cdcFile.Rows {
+				change := make(map[string]any, len(row)+2)
+				for col, value := range row {
+					change[col] = value
+				}
+				change["_commit_version"] = log.Version
+				change["_commit_timestamp"] = deltautils.CommitTimestamp(log)
+				resp.Rows = append(resp.Rows, change)
+			}
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,321 @@
+package hudi
+
+import (
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	sqlc "lakelens/internal/sqlc/generate"
+	"lakelens/internal/stash"
+	hudiutils "lakelens/internal/utils/hudi"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+type HudiService struct {
+	Queries     *sqlc.Queries
+	RedisClient *redis.Client
+	DB          *pgxpool.Pool
+
+	Stash *stash.StashService
+}
+
+func NewHudiService(queries *sqlc.Queries, redis *redis.Client, db *pgxpool.Pool, stash *stash.StashService) *HudiService {
+	return &HudiService{
+		Queries:     queries,
+		RedisClient: redis,
+		DB:          db,
+
+		Stash: stash,
+	}
+}
+
+// fetchTable returns the cached hudi table {tableid} of location {locid}.
+func (s *HudiService) fetchTable(ctx *gin.Context, userID int64, locid, tableid string) (*dto.Table, *errs.Errorf) {
+
+	locID, err := strconv.ParseInt(locid, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse location id to int64 : " + err.Error(),
+		}
+	}
+
+	locData, err := s.Queries.GetLocationData(ctx, locID)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get location data : " + err.Error(),
+		}
+	}
+
+	if locData.UserID != userID {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrUnauthorized,
+			Message:   "Requested resource does not belong to you.",
+			ReturnRaw: true,
+		}
+	}
+
+	lakeData, err := s.Queries.GetLakeData(ctx, locData.LakeID)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get lake data : " + err.Error(),
+		}
+	}
+
+	cache := new(stash.CacheMetadata)
+	var exists bool
+	cacheKey := dto.CacheKey(locData.LakeID, locData.LocID)
+
+	switch lakeData.Ptype {
+	case consts.AWSS3:
+		cache, exists = s.Stash.GetBucketS3(cacheKey)
+	case consts.MinIO:
+		cache, exists = s.Stash.GetBucketMinIO(cacheKey)
+	case consts.Azure:
+		cache, exists = s.Stash.GetBucketAzure(cacheKey)
+	case consts.GCS:
+		cache, exists = s.Stash.GetBucketGCS(cacheKey)
+	case consts.Local:
+		cache, exists = s.Stash.GetBucketLocal(cacheKey)
+
+	default:
+		// ?
+	}
+
+	if !exists {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Requested resource not found. Please rescan to fetch data.",
+			ReturnRaw: true,
+		}
+	}
+
+	table := cache.Bucket.GetTable(tableid)
+	if table == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Requested table not found in the location. Please rescan to fetch data.",
+			ReturnRaw: true,
+		}
+	}
+
+	if table.TableType != consts.HudiTable {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrResourceLocked,
+			Message:   "Requested resource is not of expected table type (hudi).",
+			ReturnRaw: true,
+		}
+	}
+
+	return table, nil
+}
+
+func (s *HudiService) GetOverview(ctx *gin.Context, userID int64, locid, tableid string) (*dto.HudiOverview, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	resp := &dto.HudiOverview{
+		FoundAt:       table.URI,
+		InstantCounts: make(map[string]int64),
+		CommitsRead:   table.Hudi.CommitsRead,
+	}
+
+	if props := table.Hudi.Properties; props != nil {
+		resp.TableName = props.TableName
+		resp.DatabaseName = props.DatabaseName
+		resp.TableType = props.TableType
+		resp.TableVersion = props.TableVersion
+		resp.TimelineLayout = props.TimelineLayout
+		resp.BaseFileFormat = props.BaseFileFormat
+		resp.RecordKeyFields = props.RecordKeyFields
+		resp.PrecombineField = props.PrecombineField
+		resp.PartitionFields = props.PartitionFields
+		resp.KeyGenerator = props.KeyGenerator
+		resp.MetadataPartitions = props.MetadataPartitions
+	}
+
+	timeline := table.Hudi.Timeline
+	if len(timeline) > 0 {
+		resp.FirstInstant = timeline[0].Timestamp
+		resp.LatestInstant = timeline[len(timeline)-1].Timestamp
+	}
+
+	for _, instant := range timeline {
+		resp.InstantCounts[instant.Action]++
+
+		if instant.State != hudiutils.StateCompleted {
+			resp.PendingInstants++
+			continue
+		}
+		if hudiutils.IsCommitAction(instant.Action) {
+			resp.LatestCommit = instant.Timestamp
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *HudiService) GetHistory(ctx *gin.Context, userID int64, locid, tableid string) (*dto.HudiHistory, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	entries := make([]*dto.HudiHistoryEntry, 0, len(table.Hudi.Timeline))
+
+	for i := len(table.Hudi.Timeline) - 1; i >= 0; i-- {
+		instant := table.Hudi.Timeline[i]
+
+		entry := &dto.HudiHistoryEntry{
+			Timestamp:      instant.Timestamp,
+			CompletionTime: instant.CompletionTime,
+			Action:         instant.Action,
+			State:          instant.State,
+		}
+
+		if commit := instant.Commit; commit != nil {
+			entry.MetadataRead = true
+			entry.OperationType = commit.OperationType
+			entry.PartitionsWritten = int64(len(commit.PartitionToWriteStats))
+
+			for _, stats := range commit.PartitionToWriteStats {
+				entry.FilesWritten += int64(len(stats))
+				for _, stat := range stats {
+					entry.NumWrites += stat.NumWrites
+					entry.NumInserts += stat.NumInserts
+					entry.NumUpdates += stat.NumUpdateWrites
+					entry.NumDeletes += stat.NumDeletes
+					entry.BytesWritten += stat.TotalWriteBytes
+					entry.WriteErrors += stat.TotalWriteErrors
+				}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return &dto.HudiHistory{
+		Instants: entries,
+	}, nil
+}
+
+// fileGroup is the latest file slice of a single file group, as built from the commits.
+type fileGroup struct {
+	partition string
+	baseFile  string
+	baseSize  int64
+	logs      map[string]int64 // log file path to its size.
+}
+
+func (s *HudiService) GetFileGroups(ctx *gin.Context, userID int64, locid, tableid string) (*dto.HudiFileGroups, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	groups := make(map[string]*fileGroup)
+	completedCommits := 0
+
+	// the commits are replayed oldest first, so every group ends up with its latest slice.
+	for _, instant := range table.Hudi.Timeline {
+		if instant.State == hudiutils.StateCompleted && hudiutils.IsCommitAction(instant.Action) {
+			completedCommits++
+		}
+		commit := instant.Commit
+		if commit == nil {
+			continue
+		}
+
+		// replace commits (clustering, insert overwrite) retire whole file groups.
+		for partition, fileIDs := range commit.PartitionToReplaceFileIDs {
+			for _, fileID := range fileIDs {
+				delete(groups, partition+"/"+fileID)
+			}
+		}
+
+		for partition, stats := range commit.PartitionToWriteStats {
+			for _, stat := range stats {
+				key := partition + "/" + stat.FileID
+				group, ok := groups[key]
+				if !ok {
+					group = &fileGroup{
+						partition: partition,
+						logs:      make(map[string]int64),
+					}
+					groups[key] = group
+				}
+
+				if strings.Contains(path.Base(stat.Path), ".log.") {
+					group.logs[stat.Path] = stat.FileSizeInBytes
+					continue
+				}
+
+				// a new base file starts a new slice, the older logs are merged into it.
+				if stat.Path != group.baseFile {
+					group.logs = make(map[string]int64)
+				}
+				group.baseFile = stat.Path
+				group.baseSize = stat.FileSizeInBytes
+			}
+		}
+	}
+
+	partitions := make(map[string]*dto.HudiPartitionFileGroups)
+	baseSizes := make(map[string]int64)
+	resp := &dto.HudiFileGroups{
+		CommitsRead: table.Hudi.CommitsRead,
+		Partial:     table.Hudi.CommitsRead < completedCommits,
+	}
+
+	for _, group := range groups {
+		part, ok := partitions[group.partition]
+		if !ok {
+			part = &dto.HudiPartitionFileGroups{
+				Partition: group.partition,
+			}
+			partitions[group.partition] = part
+		}
+
+		part.FileGroups++
+		if group.baseFile != "" {
+			part.BaseFiles++
+			part.TotalSize += group.baseSize
+			baseSizes[group.partition] += group.baseSize
+		}
+		part.LogFiles += int64(len(group.logs))
+		for _, size := range group.logs {
+			part.TotalSize += size
+		}
+	}
+
+	for _, part := range partitions {
+		if part.BaseFiles > 0 {
+			part.AvgBaseFileSize = baseSizes[part.Partition] / part.BaseFiles
+		}
+
+		resp.TotalFileGroups += part.FileGroups
+		resp.TotalBaseFiles += part.BaseFiles
+		resp.TotalLogFiles += part.LogFiles
+		resp.TotalSize += part.TotalSize
+		resp.Partitions = append(resp.Partitions, part)
+	}
+
+	slices.SortFunc(resp.Partitions, func(a, b *dto.HudiPartitionFileGroups) int {
+		return strings.Compare(a.Partition, b.Partition)
+	})
+
+	return resp, nil
+}
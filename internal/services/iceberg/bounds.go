@@ -0,0 +1,126 @@
+package iceberg
+
+import (
+	"cmp"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"slices"
+)
+
+// schemaRanges returns the min/max ranges of the schema columns over the live data files of the current
+// snapshot, overall and per file. The bounds are decoded with the types of the given schema, the columns
+// are its primitive fields, nested ones named by their dotted paths.
+func schemaRanges(table *dto.Table, schema *formats.IcebergSchema) ([]*dto.ColumnRange, []*dto.FileRange) {
+
+	columns := make([]*dto.ColumnRange, 0, len(schema.Fields))
+	for _, field := range iceutils.FlattenFields(schema.Fields) {
+		if field.Type.Nested != "" {
+			continue
+		}
+		columns = append(columns, &dto.ColumnRange{
+			ID:   field.ID,
+			Name: field.Path,
+			Type: field.Type.Primitive,
+		})
+	}
+
+	files := make([]*dto.FileRange, 0)
+	if len(table.Iceberg.Manifest) == 0 {
+		return columns, files
+	}
+
+	lowers := make(map[int64][]byte)
+	uppers := make(map[int64][]byte)
+
+	for _, data := range table.Iceberg.Manifest[0].Data {
+		if data.Metadata.Content == "deletes" {
+			continue
+		}
+
+		for i := range data.Entries {
+			entry := &data.Entries[i]
+			if !iceutils.IsLive(entry) {
+				continue
+			}
+
+			file := &dto.FileRange{
+				Path:        entry.DataFile.FilePath,
+				Partition:   iceutils.PartitionKey(entry.DataFile.Partition),
+				RecordCount: entry.DataFile.RecordCount,
+				Columns:     make(map[string]*dto.ValueRange),
+			}
+
+			lower := iceutils.Bounds(entry.DataFile.LowerBounds)
+			upper := iceutils.Bounds(entry.DataFile.UpperBounds)
+			nulls := iceutils.Counts(entry.DataFile.NullValueCounts)
+			values := iceutils.Counts(entry.DataFile.ValueCounts)
+
+			for _, column := range columns {
+				column.NullCount += nulls[column.ID]
+				column.ValueCount += values[column.ID]
+
+				lo, hasLo := lower[column.ID]
+				hi, hasHi := upper[column.ID]
+				if (!hasLo && !hasHi) || column.Error != "" {
+					continue
+				}
+
+				valueRange, err := decodeRange(column.Type, lo, hi, hasLo, hasHi)
+				if err != nil {
+					column.Error = err.Error()
+					continue
+				}
+				file.Columns[column.Name] = valueRange
+				column.FilesWithBounds++
+
+				if hasLo && (lowers[column.ID] == nil || iceutils.CompareBounds(column.Type, lo, lowers[column.ID]) < 0) {
+					lowers[column.ID] = lo
+				}
+				if hasHi && (uppers[column.ID] == nil || iceutils.CompareBounds(column.Type, hi, uppers[column.ID]) > 0) {
+					uppers[column.ID] = hi
+				}
+			}
+
+			files = append(files, file)
+		}
+	}
+
+	for _, column := range columns {
+		if column.Error != "" {
+			continue
+		}
+		// the bounds were all decoded already.
+		if lo, ok := lowers[column.ID]; ok {
+			column.Min, _ = iceutils.DecodeBound(column.Type, lo)
+		}
+		if hi, ok := uppers[column.ID]; ok {
+			column.Max, _ = iceutils.DecodeBound(column.Type, hi)
+		}
+	}
+
+	slices.SortFunc(files, func(a, b *dto.FileRange) int { return cmp.Compare(a.Path, b.Path) })
+
+	return columns, files
+}
+
+func decodeRange(typ string, lo, hi []byte, hasLo, hasHi bool) (*dto.ValueRange, error) {
+
+	valueRange := &dto.ValueRange{}
+
+	var err error
+	if hasLo {
+		valueRange.Min, err = iceutils.DecodeBound(typ, lo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if hasHi {
+		valueRange.Max, err = iceutils.DecodeBound(typ, hi)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return valueRange, nil
+}
@@ -0,0 +1,172 @@
+package iceberg
+
+import (
+	"cmp"
+	"fmt"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// addDeleteEntry adds the file of the entry to the counts of its partition.
+func addDeleteEntry(part *dto.IcebergPartitionDeletes, entry *formats.ManifestEntry) {
+
+	file := entry.DataFile
+
+	switch iceutils.FileContent(entry) {
+	case iceutils.FileContentPositionDeletes:
+		part.PositionDeleteFiles++
+		part.PositionDeleteRecords += file.RecordCount
+		part.PositionDeleteBytes += file.FileSizeInBytes
+	case iceutils.FileContentEqualityDeletes:
+		part.EqualityDeleteFiles++
+		part.EqualityDeleteRecords += file.RecordCount
+		part.EqualityDeleteBytes += file.FileSizeInBytes
+		for _, id := range iceutils.IntArray(file.EqualityIDs) {
+			if !slices.Contains(part.EqualityFieldIDs, id) {
+				part.EqualityFieldIDs = append(part.EqualityFieldIDs, id)
+			}
+		}
+	default:
+		part.DataFiles++
+		part.DataRecords += file.RecordCount
+		part.DataBytes += file.FileSizeInBytes
+	}
+}
+
+// deleteRatios sets the delete ratio and the read amplification of the partition.
+func deleteRatios(part *dto.IcebergPartitionDeletes) {
+
+	slices.Sort(part.EqualityFieldIDs)
+
+	deleteRecords := part.PositionDeleteRecords + part.EqualityDeleteRecords
+	deleteBytes := part.PositionDeleteBytes + part.EqualityDeleteBytes
+
+	if part.DataRecords != 0 {
+		part.DeleteRatio = float64(deleteRecords) / float64(part.DataRecords)
+	}
+	if part.DataBytes != 0 {
+		part.ReadAmplification = float64(part.DataBytes+deleteBytes) / float64(part.DataBytes)
+	}
+	if part.DataFiles != 0 {
+		part.MaxMergedDeletes = part.PositionDeleteFiles + part.EqualityDeleteFiles
+	}
+}
+
+// flagDeletes flags the partition for a rewrite past the given limits.
+func flagDeletes(part *dto.IcebergPartitionDeletes, ratioLimit float64, filesLimit int) {
+
+	deleteRecords := part.PositionDeleteRecords + part.EqualityDeleteRecords
+
+	if deleteRecords != 0 && part.DeleteRatio >= ratioLimit {
+		part.RewriteDataFiles = true
+		part.Reasons = append(part.Reasons, fmt.Sprintf("%.1f%% of the records are deleted, rewrite_data_files applies the deletes.", part.DeleteRatio*100))
+	}
+	if part.EqualityDeleteFiles >= int64(filesLimit) {
+		part.RewriteDataFiles = true
+		part.Reasons = append(part.Reasons, fmt.Sprintf("%d equality delete files are merged on read, rewrite_data_files applies them.", part.EqualityDeleteFiles))
+	}
+	if part.PositionDeleteFiles >= int64(filesLimit) {
+		part.RewritePositionDeletes = true
+		part.Reasons = append(part.Reasons, fmt.Sprintf("%d position delete files are merged on read, rewrite_position_delete_files compacts them.", part.PositionDeleteFiles))
+	}
+}
+
+// deleteReport analyzes the delete files of the current snapshot per partition, against the given limits.
+func deleteReport(table *dto.Table, ratioLimit float64, filesLimit int) *dto.IcebergDeleteReport {
+
+	report := &dto.IcebergDeleteReport{
+		SnapshotID: table.Iceberg.Metadata.CurrentSnapshotID,
+		Total:      &dto.IcebergPartitionDeletes{EqualityFieldIDs: make([]int64, 0), Reasons: make([]string, 0)},
+		Partitions: make([]*dto.IcebergPartitionDeletes, 0),
+	}
+
+	if len(table.Iceberg.Manifest) == 0 {
+		return report
+	}
+
+	records := make(map[string]*formats.SnapshotRecord)
+	if len(table.Iceberg.Snapshot) != 0 {
+		for _, record := range table.Iceberg.Snapshot[0].Records {
+			records[record.ManifestPath] = record
+		}
+	}
+
+	types := iceutils.SourceTypes(table.Iceberg.Metadata.Schemas)
+	parts := make(map[string]*dto.IcebergPartitionDeletes)
+
+	for _, data := range table.Iceberg.Manifest[0].Data {
+		specID, fields := specFields(table, data, records[data.URI])
+
+		for i := range data.Entries {
+			entry := &data.Entries[i]
+			if !iceutils.IsLive(entry) {
+				continue
+			}
+
+			// the same tuple under two specs are two partitions.
+			key := fmt.Sprintf("%d/%s", specID, iceutils.PartitionKey(entry.DataFile.Partition))
+			part, ok := parts[key]
+			if !ok {
+				part = &dto.IcebergPartitionDeletes{
+					SpecID:           specID,
+					EqualityFieldIDs: make([]int64, 0),
+					Reasons:          make([]string, 0),
+				}
+				part.Values, part.Partition = partitionValues(entry.DataFile.Partition, fields, types)
+				parts[key] = part
+				report.Partitions = append(report.Partitions, part)
+			}
+
+			addDeleteEntry(part, entry)
+			addDeleteEntry(report.Total, entry)
+		}
+	}
+
+	for _, part := range report.Partitions {
+		deleteRatios(part)
+		flagDeletes(part, ratioLimit, filesLimit)
+		if part.RewriteDataFiles || part.RewritePositionDeletes {
+			report.UrgentPartitions++
+		}
+	}
+	deleteRatios(report.Total)
+
+	// the flagged partitions first, the most deleted first.
+	slices.SortFunc(report.Partitions, func(a, b *dto.IcebergPartitionDeletes) int {
+		aUrgent := a.RewriteDataFiles || a.RewritePositionDeletes
+		bUrgent := b.RewriteDataFiles || b.RewritePositionDeletes
+		if aUrgent != bUrgent {
+			if aUrgent {
+				return -1
+			}
+			return 1
+		}
+		if c := cmp.Compare(b.DeleteRatio, a.DeleteRatio); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.SpecID, b.SpecID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Partition, b.Partition)
+	})
+
+	return report
+}
+
+// GetDeleteReport reports the delete files of the current snapshot per partition, with their merge-on-read
+// amplification and the partitions that need a rewrite.
+func (s *IcebergService) GetDeleteReport(ctx *gin.Context, userID int64, locid, tableid string) (*dto.IcebergDeleteReport, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return deleteReport(table, configs.Extras.IcebergDeleteRatioLimit, configs.Extras.IcebergDeleteFilesLimit), nil
+}
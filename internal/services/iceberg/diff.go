@@ -0,0 +1,211 @@
+package iceberg
+
+import (
+	"fmt"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// findSnapshot returns the snapshot {snapid} of the table metadata.
+func findSnapshot(table *dto.Table, snapid string) (*formats.IcebergMetadataSnapshot, *errs.Errorf) {
+
+	snapID, err := strconv.ParseInt(snapid, 10, 64)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse snapshot id to int64 : " + err.Error(),
+		}
+	}
+
+	for i, snap := range table.Iceberg.Metadata.Snapshots {
+		if snap.SnapshotID == snapID {
+			return &table.Iceberg.Metadata.Snapshots[i], nil
+		}
+	}
+
+	return nil, &errs.Errorf{
+		Type:      errs.ErrNotFound,
+		Message:   fmt.Sprintf("Requested snapshot %d is not retained by the table.", snapID),
+		ReturnRaw: true,
+	}
+}
+
+// walkedSnapshot returns the deep scan view of the given snapshot, if it was walked successfully.
+func walkedSnapshot(table *dto.Table, snapID int64) (*formats.IcebergSnapshotFiles, *errs.Errorf) {
+
+	files, ok := table.Iceberg.History.Snapshots[snapID]
+	if !ok {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   fmt.Sprintf("The snapshot %d was not walked by the deep scan, it is older than the snapshots limit.", snapID),
+			ReturnRaw: true,
+		}
+	}
+
+	if files.Error != "" {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   fmt.Sprintf("The snapshot %d could not be walked : %s", snapID, files.Error),
+			ReturnRaw: true,
+		}
+	}
+
+	return files, nil
+}
+
+func findSchemaByID(table *dto.Table, schemaID int64) *formats.IcebergSchema {
+
+	for i, schema := range table.Iceberg.Metadata.Schemas {
+		if schema.SchemaID == schemaID {
+			return &table.Iceberg.Metadata.Schemas[i]
+		}
+	}
+
+	return nil
+}
+
+// dataSpecIDs returns the partition spec ids of the data manifests of the snapshot, sorted.
+func dataSpecIDs(files *formats.IcebergSnapshotFiles) []int64 {
+
+	ids := make([]int64, 0)
+	for _, record := range files.Manifests {
+		if record.Content == iceutils.ContentData && !slices.Contains(ids, int64(record.PartitionSpecID)) {
+			ids = append(ids, int64(record.PartitionSpecID))
+		}
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func diffFile(entry *formats.ManifestEntry) *dto.IcebergDiffFile {
+	return &dto.IcebergDiffFile{
+		Path:            entry.DataFile.FilePath,
+		Content:         iceutils.FileContentName(iceutils.FileContent(entry)),
+		Partition:       iceutils.PartitionKey(entry.DataFile.Partition),
+		RecordCount:     entry.DataFile.RecordCount,
+		FileSizeInBytes: entry.DataFile.FileSizeInBytes,
+	}
+}
+
+// GetSnapshotDiff returns the files added and removed from snapshot {from} to snapshot {to}, with the schema
+// and partition spec changes between them. Needs the deep scan.
+func (s *IcebergService) GetSnapshotDiff(ctx *gin.Context, userID int64, locid, tableid, from, to string) (*dto.IcebergSnapshotDiff, *errs.Errorf) {
+
+	table, errf := s.fetchHistory(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return snapshotDiff(table, from, to)
+}
+
+func snapshotDiff(table *dto.Table, from, to string) (*dto.IcebergSnapshotDiff, *errs.Errorf) {
+
+	fromSnap, errf := findSnapshot(table, from)
+	if errf != nil {
+		return nil, errf
+	}
+	toSnap, errf := findSnapshot(table, to)
+	if errf != nil {
+		return nil, errf
+	}
+
+	fromFiles, errf := walkedSnapshot(table, fromSnap.SnapshotID)
+	if errf != nil {
+		return nil, errf
+	}
+	toFiles, errf := walkedSnapshot(table, toSnap.SnapshotID)
+	if errf != nil {
+		return nil, errf
+	}
+
+	resp := &dto.IcebergSnapshotDiff{
+		FromSnapshotID:  fromSnap.SnapshotID,
+		ToSnapshotID:    toSnap.SnapshotID,
+		FromTimestampMS: fromSnap.TimestampMS,
+		ToTimestampMS:   toSnap.TimestampMS,
+
+		DataFilesAdded:     make([]*dto.IcebergDiffFile, 0),
+		DataFilesRemoved:   make([]*dto.IcebergDiffFile, 0),
+		DeleteFilesAdded:   make([]*dto.IcebergDiffFile, 0),
+		DeleteFilesRemoved: make([]*dto.IcebergDiffFile, 0),
+		PartitionsTouched:  make([]string, 0),
+
+		FromSchemaID:  fromSnap.SchemaID,
+		ToSchemaID:    toSnap.SchemaID,
+		SchemaChanges: make([]*dto.IcebergFieldChange, 0),
+
+		FromSpecIDs: dataSpecIDs(fromFiles),
+		ToSpecIDs:   dataSpecIDs(toFiles),
+	}
+
+	fromLive := iceutils.LiveFiles(fromFiles, table.Iceberg.History.Manifests)
+	toLive := iceutils.LiveFiles(toFiles, table.Iceberg.History.Manifests)
+	partitions := make(map[string]bool)
+
+	for filePath, entry := range toLive {
+		if _, ok := fromLive[filePath]; ok {
+			continue
+		}
+
+		file := diffFile(entry)
+		partitions[file.Partition] = true
+		resp.BytesAdded += file.FileSizeInBytes
+		if iceutils.FileContent(entry) == iceutils.FileContentData {
+			resp.DataFilesAdded = append(resp.DataFilesAdded, file)
+			resp.RecordsAdded += file.RecordCount
+		} else {
+			resp.DeleteFilesAdded = append(resp.DeleteFilesAdded, file)
+		}
+	}
+
+	for filePath, entry := range fromLive {
+		if _, ok := toLive[filePath]; ok {
+			continue
+		}
+
+		file := diffFile(entry)
+		partitions[file.Partition] = true
+		resp.BytesRemoved += file.FileSizeInBytes
+		if iceutils.FileContent(entry) == iceutils.FileContentData {
+			resp.DataFilesRemoved = append(resp.DataFilesRemoved, file)
+			resp.RecordsRemoved += file.RecordCount
+		} else {
+			resp.DeleteFilesRemoved = append(resp.DeleteFilesRemoved, file)
+		}
+	}
+
+	resp.NetRecords = resp.RecordsAdded - resp.RecordsRemoved
+	resp.NetBytes = resp.BytesAdded - resp.BytesRemoved
+
+	for partition := range partitions {
+		resp.PartitionsTouched = append(resp.PartitionsTouched, partition)
+	}
+	slices.Sort(resp.PartitionsTouched)
+
+	byPath := func(a, b *dto.IcebergDiffFile) int { return strings.Compare(a.Path, b.Path) }
+	slices.SortFunc(resp.DataFilesAdded, byPath)
+	slices.SortFunc(resp.DataFilesRemoved, byPath)
+	slices.SortFunc(resp.DeleteFilesAdded, byPath)
+	slices.SortFunc(resp.DeleteFilesRemoved, byPath)
+
+	if fromSnap.SchemaID != toSnap.SchemaID {
+		fromSchema := findSchemaByID(table, fromSnap.SchemaID)
+		toSchema := findSchemaByID(table, toSnap.SchemaID)
+		if fromSchema != nil && toSchema != nil {
+			resp.SchemaChanges = diffSchemas(fromSchema, toSchema)
+		}
+		resp.SchemaChanged = true
+	}
+	resp.SpecChanged = !slices.Equal(resp.FromSpecIDs, resp.ToSpecIDs)
+
+	return resp, nil
+}
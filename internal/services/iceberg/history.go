@@ -0,0 +1,86 @@
+package iceberg
+
+import (
+	"cmp"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fetchHistory returns the table {tableid} of location {locid}, with the snapshots history of the deep scan.
+func (s *IcebergService) fetchHistory(ctx *gin.Context, userID int64, locid, tableid string) (*dto.Table, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	if table.Iceberg.History == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "The snapshots history was not scanned. Please rescan the location with IcebergDeepScan=true.",
+			ReturnRaw: true,
+		}
+	}
+
+	return table, nil
+}
+
+func fileChanges(counts formats.IcebergFileCounts) dto.IcebergFileChanges {
+	return dto.IcebergFileChanges{
+		AddedFiles:      counts.AddedFiles,
+		DeletedFiles:    counts.DeletedFiles,
+		ExistingFiles:   counts.ExistingFiles,
+		AddedRecords:    counts.AddedRecords,
+		DeletedRecords:  counts.DeletedRecords,
+		ExistingRecords: counts.ExistingRecords,
+		AddedBytes:      counts.AddedBytes,
+		DeletedBytes:    counts.DeletedBytes,
+		ExistingBytes:   counts.ExistingBytes,
+	}
+}
+
+// GetHistory returns the data and delete files added, deleted and carried over by every snapshot walked.
+func (s *IcebergService) GetHistory(ctx *gin.Context, userID int64, locid, tableid string) (*dto.IcebergHistory, *errs.Errorf) {
+
+	table, errf := s.fetchHistory(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	history := table.Iceberg.History
+	resp := &dto.IcebergHistory{
+		SnapshotsRead: history.SnapshotsRead,
+		ManifestsRead: len(history.Manifests),
+		Partial:       history.Partial,
+		Snapshots:     make([]*dto.IcebergSnapshotHistory, 0, len(history.Snapshots)),
+	}
+
+	for _, snap := range table.Iceberg.Metadata.Snapshots {
+		files, ok := history.Snapshots[snap.SnapshotID]
+		if !ok {
+			continue
+		}
+
+		resp.Snapshots = append(resp.Snapshots, &dto.IcebergSnapshotHistory{
+			SnapshotID:       snap.SnapshotID,
+			ParentSnapshotID: snap.ParentSnapshotID,
+			TimestampMS:      snap.TimestampMS,
+			Operation:        snap.Summary.Operation,
+			Manifests:        int64(len(files.Manifests)),
+			Error:            files.Error,
+
+			Data:    fileChanges(files.Data),
+			Deletes: fileChanges(files.Deletes),
+		})
+	}
+
+	slices.SortFunc(resp.Snapshots, func(a, b *dto.IcebergSnapshotHistory) int {
+		return cmp.Compare(b.TimestampMS, a.TimestampMS)
+	})
+
+	return resp, nil
+}
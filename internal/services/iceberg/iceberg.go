@@ -1,8 +1,6 @@
 package iceberg
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
 	"lakelens/internal/consts"
 	"lakelens/internal/consts/errs"
@@ -10,6 +8,7 @@ import (
 	formats "lakelens/internal/dto/formats/iceberg"
 	sqlc "lakelens/internal/sqlc/generate"
 	"lakelens/internal/stash"
+	iceutils "lakelens/internal/utils/iceberg"
 	"slices"
 	"strconv"
 
@@ -36,7 +35,8 @@ func NewIcebergService(queries *sqlc.Queries, redis *redis.Client, db *pgxpool.P
 	}
 }
 
-func (s *IcebergService) fetchCache(ctx *gin.Context, userID int64, locid string) (*stash.CacheMetadata, *errs.Errorf) {
+// fetchTable returns the cached iceberg table {tableid} of location {locid}.
+func (s *IcebergService) fetchTable(ctx *gin.Context, userID int64, locid, tableid string) (*dto.Table, *errs.Errorf) {
 
 	locID, err := strconv.ParseInt(locid, 10, 64)
 	if err != nil {
@@ -72,10 +72,19 @@ func (s *IcebergService) fetchCache(ctx *gin.Context, userID int64, locid string
 
 	cache := new(stash.CacheMetadata)
 	var exists bool
+	cacheKey := dto.CacheKey(locData.LakeID, locData.LocID)
 
 	switch lakeData.Ptype {
 	case consts.AWSS3:
-		cache, exists = s.Stash.GetBucketS3(locData.BucketName)
+		cache, exists = s.Stash.GetBucketS3(cacheKey)
+	case consts.MinIO:
+		cache, exists = s.Stash.GetBucketMinIO(cacheKey)
+	case consts.Azure:
+		cache, exists = s.Stash.GetBucketAzure(cacheKey)
+	case consts.GCS:
+		cache, exists = s.Stash.GetBucketGCS(cacheKey)
+	case consts.Local:
+		cache, exists = s.Stash.GetBucketLocal(cacheKey)
 
 	default:
 		// ?
@@ -89,7 +98,16 @@ func (s *IcebergService) fetchCache(ctx *gin.Context, userID int64, locid string
 		}
 	}
 
-	if cache.Bucket.Data.TableType != consts.IcebergTable {
+	table := cache.Bucket.GetTable(tableid)
+	if table == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Requested table not found in the location. Please rescan to fetch data.",
+			ReturnRaw: true,
+		}
+	}
+
+	if table.TableType != consts.IcebergTable || table.Iceberg.Metadata == nil {
 		return nil, &errs.Errorf{
 			Type:      errs.ErrResourceLocked,
 			Message:   "Requested resource is not of expected table type (iceberg).",
@@ -97,21 +115,21 @@ func (s *IcebergService) fetchCache(ctx *gin.Context, userID int64, locid string
 		}
 	}
 
-	return cache, nil
+	return table, nil
 }
 
-func (s *IcebergService) GetOverviewData(ctx *gin.Context, userID int64, locid string) (*dto.OverviewData, *errs.Errorf) {
+func (s *IcebergService) GetOverviewData(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewData, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	fileURIs := make(map[string]string, 0)
-	fileURIs["Metadata File"] = cache.Bucket.Iceberg.MetadataFPaths[len(cache.Bucket.Iceberg.MetadataFPaths)-1]
+	fileURIs["Metadata File"] = table.Iceberg.MetadataFPaths[len(table.Iceberg.MetadataFPaths)-1]
 
-	currSnapID := cache.Bucket.Iceberg.Metadata.CurrentSnapshotID
-	for _, snap := range cache.Bucket.Iceberg.Metadata.Snapshots {
+	currSnapID := table.Iceberg.Metadata.CurrentSnapshotID
+	for _, snap := range table.Iceberg.Metadata.Snapshots {
 		if snap.SnapshotID == currSnapID {
 			fileURIs["Snapshot File"] = snap.ManifestList
 			break
@@ -119,33 +137,39 @@ func (s *IcebergService) GetOverviewData(ctx *gin.Context, userID int64, locid s
 	}
 
 	return &dto.OverviewData{
-		FoundAt:     cache.Bucket.Iceberg.URI,
-		Location:    cache.Bucket.Iceberg.Metadata.Location,
-		TableUUID:   cache.Bucket.Iceberg.Metadata.TableUUID,
+		FoundAt:     table.Iceberg.URI,
+		Location:    table.Iceberg.Metadata.Location,
+		TableUUID:   table.Iceberg.Metadata.TableUUID,
 		FilesReadMp: map[string]int64{},
-		TableType:   cache.Bucket.Data.TableType,
+		TableType:   table.TableType,
 		FileURIs:    fileURIs,
 	}, nil
 }
 
-func (s *IcebergService) GetOverviewStats(ctx *gin.Context, userID int64, locid string) (*dto.OverviewStats, *errs.Errorf) {
+func (s *IcebergService) GetOverviewStats(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewStats, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	var latestSnap formats.IcebergMetadataSnapshot
 
-	currSnapID := cache.Bucket.Iceberg.Metadata.CurrentSnapshotID
-	for _, snap := range cache.Bucket.Iceberg.Metadata.Snapshots {
+	currSnapID := table.Iceberg.Metadata.CurrentSnapshotID
+	for _, snap := range table.Iceberg.Metadata.Snapshots {
 		if snap.SnapshotID == currSnapID {
 			latestSnap = snap
 			break
 		}
 	}
 
-	snapSummary := latestSnap.Summary
+	return overviewStats(table, &latestSnap), nil
+}
+
+// overviewStats returns the stats of the table at the given snapshot, from its summary.
+func overviewStats(table *dto.Table, snap *formats.IcebergMetadataSnapshot) *dto.OverviewStats {
+
+	snapSummary := snap.Summary
 
 	addrecs, _ := strconv.ParseInt(snapSummary.AddedRecords, 10, 64)
 	addposdels, _ := strconv.ParseInt(snapSummary.AddedPositionDeletes, 10, 64)
@@ -155,74 +179,84 @@ func (s *IcebergService) GetOverviewStats(ctx *gin.Context, userID int64, locid
 
 	totSize, _ := strconv.ParseInt(snapSummary.TotalFilesSize, 10, 64)
 	totDataFiles, _ := strconv.ParseInt(snapSummary.TotalDataFiles, 10, 64)
-	avgFileSize := totSize / totDataFiles
+
+	var avgFileSize int64
+	if totDataFiles != 0 {
+		avgFileSize = totSize / totDataFiles
+	}
 
 	return &dto.OverviewStats{
 		Table: dto.OverviewStatsTable{
-			TableType:    cache.Bucket.Data.TableType,
-			TableVersion: cache.Bucket.Iceberg.Metadata.FormatVersion,
+			TableType:    table.TableType,
+			TableVersion: table.Iceberg.Metadata.FormatVersion,
 		},
 		Rows: dto.OverviewStatsRowCount{
 			TotalCount: snapSummary.TotalRecords,
 			DeltaCount: delta,
 		},
 		Version: dto.OverviewStatsVersion{
-			CurrentVersion: strconv.FormatInt(cache.Bucket.Iceberg.Metadata.CurrentSnapshotID, 10),
-			LastSnapshot:   latestSnap.TimestampMS,
-			TotalSnapshots: latestSnap.SequenceNumber,
+			CurrentVersion: strconv.FormatInt(snap.SnapshotID, 10),
+			LastSnapshot:   snap.TimestampMS,
+			TotalSnapshots: snap.SequenceNumber,
 		},
 		Storage: dto.OverviewStatsStorage{
 			TotalSize:      totSize,
 			TotalDataFiles: totDataFiles,
 			AvgFileSize:    avgFileSize,
 		},
-	}, nil
+	}
 }
 
-func (s *IcebergService) GetOverviewSchema(ctx *gin.Context, userID int64, locid string) (*dto.OverviewSchema, *errs.Errorf) {
+func (s *IcebergService) GetOverviewSchema(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewSchema, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	var latestSchema formats.IcebergSchema
 
-	currSchID := cache.Bucket.Iceberg.Metadata.CurrentSchemaID
-	for _, schema := range cache.Bucket.Iceberg.Metadata.Schemas {
+	currSchID := table.Iceberg.Metadata.CurrentSchemaID
+	for _, schema := range table.Iceberg.Metadata.Schemas {
 		if schema.SchemaID == currSchID {
 			latestSchema = schema
 			break
 		}
 	}
 
+	return overviewSchema(&latestSchema), nil
+}
+
+// overviewSchema returns the fields of the given schema, nested fields flattened to their dotted paths.
+func overviewSchema(schema *formats.IcebergSchema) *dto.OverviewSchema {
+
 	fields := make([]*dto.OverviewSchemaField, 0)
-	for _, field := range latestSchema.Fields {
+	for _, field := range iceutils.FlattenFields(schema.Fields) {
 		fields = append(fields, &dto.OverviewSchemaField{
 			ID:       field.ID,
-			Name:     field.Name,
-			Type:     field.Type,
+			Name:     field.Path,
+			Type:     field.Type.String(),
 			Required: field.Required,
 		})
 	}
 
 	return &dto.OverviewSchema{
-		SchemaID: latestSchema.SchemaID,
+		SchemaID: schema.SchemaID,
 		Fields:   fields,
-	}, nil
+	}
 }
 
-func (s *IcebergService) GetOverviewPartition(ctx *gin.Context, userID int64, locid string) (*dto.OverviewPartition, *errs.Errorf) {
+func (s *IcebergService) GetOverviewPartition(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewPartition, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	var latestSpec formats.IcebergPartitionSpec
 
-	currSpecID := cache.Bucket.Iceberg.Metadata.DefaultSpecID
-	for _, spec := range cache.Bucket.Iceberg.Metadata.PartitionSpecs {
+	currSpecID := table.Iceberg.Metadata.DefaultSpecID
+	for _, spec := range table.Iceberg.Metadata.PartitionSpecs {
 		if spec.SpecID == currSpecID {
 			latestSpec = spec
 			break
@@ -239,30 +273,23 @@ func (s *IcebergService) GetOverviewPartition(ctx *gin.Context, userID int64, lo
 		})
 	}
 
-	fields = append(fields, &dto.OverviewPartitionField{
-		Name:      "demo-partition",
-		Transform: "bucket[11]",
-		FieldID:   4311,
-		SourceID:  5555,
-	})
-
 	return &dto.OverviewPartition{
 		DefaultSpecID: latestSpec.SpecID,
 		Fields:        fields,
 	}, nil
 }
 
-func (s *IcebergService) GetOverviewSnapshot(ctx *gin.Context, userID int64, locid string) (*dto.OverviewSnapshot, *errs.Errorf) {
+func (s *IcebergService) GetOverviewSnapshot(ctx *gin.Context, userID int64, locid, tableid string) (*dto.OverviewSnapshot, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	var latestSnap formats.IcebergMetadataSnapshot
 
-	currSnapID := cache.Bucket.Iceberg.Metadata.CurrentSnapshotID
-	for _, snap := range cache.Bucket.Iceberg.Metadata.Snapshots {
+	currSnapID := table.Iceberg.Metadata.CurrentSnapshotID
+	for _, snap := range table.Iceberg.Metadata.Snapshots {
 		if snap.SnapshotID == currSnapID {
 			latestSnap = snap
 			break
@@ -279,14 +306,14 @@ func (s *IcebergService) GetOverviewSnapshot(ctx *gin.Context, userID int64, loc
 
 }
 
-func (s *IcebergService) GetOverviewGraphs(ctx *gin.Context, userID int64, locid string) ([]*dto.OverviewGraphs, *errs.Errorf) {
+func (s *IcebergService) GetOverviewGraphs(ctx *gin.Context, userID int64, locid, tableid string) ([]*dto.OverviewGraphs, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
-	snapshots := cache.Bucket.Iceberg.Metadata.Snapshots
+	snapshots := table.Iceberg.Metadata.Snapshots
 
 	resp := make([]*dto.OverviewGraphs, 0)
 	for _, snapshot := range snapshots {
@@ -304,16 +331,16 @@ func (s *IcebergService) GetOverviewGraphs(ctx *gin.Context, userID int64, locid
 
 // >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 
-func (s *IcebergService) GetSchemasList(ctx *gin.Context, userID int64, locid string) (*dto.SchemaList, *errs.Errorf) {
+func (s *IcebergService) GetSchemasList(ctx *gin.Context, userID int64, locid, tableid string) (*dto.SchemaList, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
 
 	abc := make(map[int64]*dto.SchemaListData, 0)
 
-	snaps := cache.Bucket.Iceberg.Metadata.Snapshots
+	snaps := table.Iceberg.Metadata.Snapshots
 
 	for _, snap := range snaps {
 
@@ -330,9 +357,9 @@ func (s *IcebergService) GetSchemasList(ctx *gin.Context, userID int64, locid st
 	}, nil
 }
 
-func (s *IcebergService) GetSchema(ctx *gin.Context, userID int64, locid string, schemaid string) (*dto.Schema, *errs.Errorf) {
+func (s *IcebergService) GetSchema(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.Schema, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
@@ -345,7 +372,7 @@ func (s *IcebergService) GetSchema(ctx *gin.Context, userID int64, locid string,
 		}
 	}
 
-	schemas := cache.Bucket.Iceberg.Metadata.Schemas
+	schemas := table.Iceberg.Metadata.Schemas
 
 	var schema formats.IcebergSchema
 	for _, sch := range schemas {
@@ -356,12 +383,16 @@ func (s *IcebergService) GetSchema(ctx *gin.Context, userID int64, locid string,
 	}
 
 	fields := make([]*dto.SchemaField, 0)
-	for _, field := range schema.Fields {
+	for _, field := range iceutils.FlattenFields(schema.Fields) {
 		fields = append(fields, &dto.SchemaField{
-			ID:       field.ID,
-			Name:     field.Name,
-			Type:     field.Type,
-			Required: field.Required,
+			ID:             field.ID,
+			Name:           field.Path,
+			Type:           field.Type.String(),
+			Required:       field.Required,
+			ParentID:       field.ParentID,
+			Doc:            field.Doc,
+			InitialDefault: field.InitialDefault,
+			WriteDefault:   field.WriteDefault,
 		})
 	}
 
@@ -377,108 +408,91 @@ func (s *IcebergService) GetSchema(ctx *gin.Context, userID int64, locid string,
 
 	// }
 
-	// manifests := cache.Bucket.Iceberg.Manifest
-
-	// currManifest := manifests[0].Data
-
-	// for _, mani := range currManifest {
-
-	// 	fmt.Println(mani.URI)
-	// 	fmt.Println(mani.Metadata.Schema)
-	// 	// fmt.Println(mani.Metadata.Content)
-	// 	for _, entry := range mani.Entries {
-
-	// 		fmt.Println(entry.DataFile.ColumnSizes)
-
-	// 		// for _, raw := range entry.DataFile.LowerBounds {
-	// 		// 	for _, r := range raw.([]any) {
-	// 		// 		rmap, ok := r.(map[string]any)
-	// 		// 		if !ok {
-	// 		// 			fmt.Println("not a map")
-	// 		// 			continue
-	// 		// 		}
-
-	// 		// 		if rmap["key"].(int32) == 2 {
-
-	// 		// 			fmt.Println("🗝️ Key:", rmap["key"])
-	// 		// 			switch val := rmap["value"].(type) {
-	// 		// 			case []byte:
-	// 		// 				printByteGuess(val)
-	// 		// 			case string:
-	// 		// 				printByteGuess([]byte(val))
-	// 		// 			default:
-	// 		// 				fmt.Println("⚠️ Unknown type:", reflect.TypeOf(val))
-	// 		// 			}
-	// 		// 		}
-	// 		// 	}
-
-	// 		// }
-	// 	}
-	// }
-
 	return &dto.Schema{
 		SchemaID: schemaID,
 		Fields:   fields,
 	}, nil
 }
 
-func printByteGuess(b []byte) {
-	if len(b) == 4 {
-		var v int32
-		_ = binary.Read(bytes.NewReader(b), binary.LittleEndian, &v)
-		fmt.Println("🧩 Guessed int32:", v)
+func (s *IcebergService) GetSchemaData(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.SchemaData, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
 	}
 
-	if len(b) == 8 {
-		var i64 int64
-		var f64 float64
+	var schemaID int64
+	var err error
 
-		err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &i64)
+	if schemaid == "latest" {
+		schemaID = table.Iceberg.Metadata.CurrentSchemaID
+	} else {
+		schemaID, err = strconv.ParseInt(schemaid, 10, 64)
 		if err != nil {
-			fmt.Println(err)
-		} else {
-			fmt.Println("🧩 Guessed int64:", i64)
+			return nil, &errs.Errorf{
+				Type:    errs.ErrInvalidInput,
+				Message: "Failed to parse schema id to int64 : " + err.Error(),
+			}
 		}
-
-		_ = binary.Read(bytes.NewReader(b), binary.LittleEndian, &f64)
-		fmt.Println("🧪 Guessed float64:", f64)
 	}
 
-	fmt.Println("📜 As string (maybe):", string(b))
-}
-
-func (s *IcebergService) GetSchemaData(ctx *gin.Context, userID int64, locid string, schemaid string) (*dto.SchemaData, *errs.Errorf) {
+	schema := findSchemaByID(table, schemaID)
+	if schema == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   fmt.Sprintf("Schema %d not found in the table.", schemaID),
+			ReturnRaw: true,
+		}
+	}
 
-	// cache, errf := s.fetchCache(ctx, userID, locid)
-	// if errf != nil {
-	// 	return nil, errf
-	// }
+	metadata := table.Iceberg.Metadata
 
-	// schemaID, err := strconv.ParseInt(schemaid, 10, 64)
-	// if err != nil {
-	// 	return nil, &errs.Errorf{
-	// 		Type:    errs.ErrInvalidInput,
-	// 		Message: "Failed to parse schema id to int64 : " + err.Error(),
-	// 	}
-	// }
+	related := make([]int64, 0)
+	for _, snap := range metadata.Snapshots {
+		if snap.SchemaID == schemaID {
+			related = append(related, snap.SnapshotID)
+		}
+	}
 
-	// schemas := cache.Bucket.Iceberg.Metadata.Schemas
+	colSizeMap := make(map[int64]int64)
+	if len(table.Iceberg.Manifest) != 0 {
+		for _, data := range table.Iceberg.Manifest[0].Data {
+			if data.Metadata.Content == "deletes" {
+				continue
+			}
+			for _, entry := range data.Entries {
+				for id, size := range iceutils.Counts(entry.DataFile.ColumnSizes) {
+					colSizeMap[id] += size
+				}
+			}
+		}
+	}
 
-	// var schema formats.IcebergSchema
-	// for _, sch := range schemas {
-	// 	if sch.SchemaID == schemaID {
-	// 		schema = sch
-	// 		break
-	// 	}
-	// }
+	columnSizes := make(map[string]int64)
+	for _, field := range iceutils.FlattenFields(schema.Fields) {
+		if size, ok := colSizeMap[field.ID]; ok {
+			columnSizes[field.Path] = size
+		}
+	}
 
-	return &dto.SchemaData{}, nil
+	columns, files := schemaRanges(table, schema)
 
+	return &dto.SchemaData{
+		LastUpdatedMS:    metadata.LastUpdatedMS,
+		LastColumnID:     metadata.LastColumnID,
+		CurrentSchemaID:  metadata.CurrentSchemaID,
+		SchemaType:       schema.Type,
+		SchemaID:         schema.SchemaID,
+		RelatedSnapshots: related,
+		ColumnSizes:      columnSizes,
+		Columns:          columns,
+		Files:            files,
+	}, nil
 }
 
-func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid string, schemaid string) (*dto.SchemaColSizes, *errs.Errorf) {
+func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid, tableid, schemaid string) (*dto.SchemaColSizes, *errs.Errorf) {
 
-	cache, errf := s.fetchCache(ctx, userID, locid)
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
 	if errf != nil {
 		return nil, errf
 	}
@@ -487,7 +501,7 @@ func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid
 	var err error
 
 	if schemaid == "latest" {
-		currSchemaID = cache.Bucket.Iceberg.Metadata.CurrentSchemaID
+		currSchemaID = table.Iceberg.Metadata.CurrentSchemaID
 	} else {
 		currSchemaID, err = strconv.ParseInt(schemaid, 10, 64)
 		if err != nil {
@@ -498,7 +512,7 @@ func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid
 		}
 	}
 
-	schemas := cache.Bucket.Iceberg.Metadata.Schemas
+	schemas := table.Iceberg.Metadata.Schemas
 
 	var schema formats.IcebergSchema
 	for _, sch := range schemas {
@@ -512,7 +526,7 @@ func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid
 	nullsCountMap := make(map[int64]int64)
 	valsCountMap := make(map[int64]int64)
 
-	mani := cache.Bucket.Iceberg.Manifest[0]
+	mani := table.Iceberg.Manifest[0]
 	for _, data := range mani.Data {
 		if data.Metadata.Content != "deletes" {
 			for _, entry := range data.Entries {
@@ -536,12 +550,13 @@ func (s *IcebergService) GetSchemaColSizes(ctx *gin.Context, userID int64, locid
 
 	result := make([]dto.ColSize, 0)
 
+	flatFields := iceutils.FlattenFields(schema.Fields)
 	for key, val := range colSizeMap {
-		for _, f := range schema.Fields {
+		for _, f := range flatFields {
 			if f.ID == key {
 				result = append(result, dto.ColSize{
 					ID:            f.ID,
-					Name:          f.Name,
+					Name:          f.Path,
 					Size:          val,
 					NullCount:     nullsCountMap[key],
 					ValueCount:    valsCountMap[key],
@@ -603,7 +618,7 @@ func (s *IcebergService) expandDataFile(dataMap map[string]any, resultMap map[in
 // 		return nil, errf
 // 	}
 
-// 	return &cache.Bucket.Iceberg, nil
+// 	return &table.Iceberg, nil
 // }
 
 // func (s *IcebergService) Metadata(ctx *gin.Context, userID int64, locid string) (*dto.IcebergMetadata, *errs.Errorf) {
@@ -613,7 +628,7 @@ func (s *IcebergService) expandDataFile(dataMap map[string]any, resultMap map[in
 // 		return nil, errf
 // 	}
 
-// 	return cache.Bucket.Iceberg.Metadata, nil
+// 	return table.Iceberg.Metadata, nil
 // }
 
 // func (s *IcebergService) Snapshot(ctx *gin.Context, userID int64, locid string) (*dto.IcebergSnapshot, *errs.Errorf) {
@@ -623,7 +638,7 @@ func (s *IcebergService) expandDataFile(dataMap map[string]any, resultMap map[in
 // 		return nil, errf
 // 	}
 
-// 	return cache.Bucket.Iceberg.Snapshot, nil
+// 	return table.Iceberg.Snapshot, nil
 // }
 
 // func (s *IcebergService) Manifest(ctx *gin.Context, userID int64, locid string) ([]*dto.IcebergManifest, *errs.Errorf) {
@@ -633,5 +648,5 @@ func (s *IcebergService) expandDataFile(dataMap map[string]any, resultMap map[in
 // 		return nil, errf
 // 	}
 
-// 	return cache.Bucket.Iceberg.Manifest, nil
+// 	return table.Iceberg.Manifest, nil
 // }
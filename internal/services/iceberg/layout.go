@@ -0,0 +1,251 @@
+package iceberg
+
+import (
+	"cmp"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// layoutUsage returns the usage of a spec or sort order by the given snapshots, oldest first.
+func layoutUsage(snapIDs map[int64]bool, timestamps map[int64]int64) dto.IcebergLayoutUsage {
+
+	usage := dto.IcebergLayoutUsage{
+		SnapshotIDs: make([]int64, 0, len(snapIDs)),
+	}
+	for id := range snapIDs {
+		usage.SnapshotIDs = append(usage.SnapshotIDs, id)
+	}
+
+	slices.SortFunc(usage.SnapshotIDs, func(a, b int64) int {
+		if c := cmp.Compare(timestamps[a], timestamps[b]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	if n := len(usage.SnapshotIDs); n != 0 {
+		usage.FirstSnapshotID = usage.SnapshotIDs[0]
+		usage.FirstTimestampMS = timestamps[usage.FirstSnapshotID]
+		usage.LastSnapshotID = usage.SnapshotIDs[n-1]
+		usage.LastTimestampMS = timestamps[usage.LastSnapshotID]
+	}
+
+	return usage
+}
+
+func addUsage(usages map[int64]map[int64]bool, layoutID, snapID int64) {
+
+	if usages[layoutID] == nil {
+		usages[layoutID] = make(map[int64]bool)
+	}
+	usages[layoutID][snapID] = true
+}
+
+// layoutTimeline returns the partition specs and sort orders of the table with the snapshots that used them.
+//
+// The specs are used by the snapshots listing their manifests, the sort orders by the snapshots that added data
+// files with them. Without the deep scan only the manifest list of the current snapshot is read, the sort orders
+// still get the snapshots that added its live files.
+func layoutTimeline(table *dto.Table) *dto.IcebergLayoutTimeline {
+
+	metadata := table.Iceberg.Metadata
+
+	timestamps := make(map[int64]int64, len(metadata.Snapshots))
+	for _, snap := range metadata.Snapshots {
+		timestamps[snap.SnapshotID] = snap.TimestampMS
+	}
+
+	resp := &dto.IcebergLayoutTimeline{
+		Specs:      make([]*dto.IcebergSpecVersion, 0),
+		SortOrders: make([]*dto.IcebergSortOrderVersion, 0),
+	}
+
+	// the manifest lists read by snapshot, and the manifests read by path.
+	lists := make(map[int64][]*formats.SnapshotRecord)
+	manifests := make(map[string]*formats.ManifestData)
+
+	if history := table.Iceberg.History; history != nil {
+		for id, files := range history.Snapshots {
+			if files.Error == "" {
+				lists[id] = files.Manifests
+			}
+		}
+		manifests = history.Manifests
+		resp.Partial = history.Partial
+	} else {
+		if len(table.Iceberg.Snapshot) != 0 {
+			lists[metadata.CurrentSnapshotID] = table.Iceberg.Snapshot[0].Records
+		}
+		if len(table.Iceberg.Manifest) != 0 {
+			for _, data := range table.Iceberg.Manifest[0].Data {
+				manifests[data.URI] = data
+			}
+		}
+		resp.Partial = len(metadata.Snapshots) > len(lists)
+	}
+	resp.SnapshotsRead = len(lists)
+
+	specUsages := make(map[int64]map[int64]bool)
+	records := make(map[string]*formats.SnapshotRecord)
+	for snapID, list := range lists {
+		for _, record := range list {
+			addUsage(specUsages, int64(record.PartitionSpecID), snapID)
+			records[record.ManifestPath] = record
+		}
+	}
+
+	orderUsages := make(map[int64]map[int64]bool)
+	for path, data := range manifests {
+		record, ok := records[path]
+		if !ok || record.Content == iceutils.ContentDeletes {
+			continue
+		}
+		for i := range data.Entries {
+			entry := &data.Entries[i]
+			// the existing entries keep the id of the snapshot that added them, the deleted ones get the deleting one.
+			if !iceutils.IsLive(entry) || entry.DataFile.SortOrderID == nil {
+				continue
+			}
+			addUsage(orderUsages, *entry.DataFile.SortOrderID, iceutils.EntrySnapshotID(entry, record))
+		}
+	}
+
+	specLive := make(map[int64]*dto.IcebergLayoutUsage)
+	orderLive := make(map[int64]*dto.IcebergLayoutUsage)
+	liveUsage := func(lives map[int64]*dto.IcebergLayoutUsage, id int64, file *formats.ManifestDataFile) {
+		if lives[id] == nil {
+			lives[id] = &dto.IcebergLayoutUsage{}
+		}
+		lives[id].LiveDataFiles++
+		lives[id].LiveDataBytes += file.FileSizeInBytes
+		lives[id].LiveRecords += file.RecordCount
+	}
+
+	currRecords := make(map[string]*formats.SnapshotRecord)
+	if len(table.Iceberg.Snapshot) != 0 {
+		for _, record := range table.Iceberg.Snapshot[0].Records {
+			currRecords[record.ManifestPath] = record
+		}
+	}
+
+	if len(table.Iceberg.Manifest) != 0 {
+		for _, data := range table.Iceberg.Manifest[0].Data {
+			record, ok := currRecords[data.URI]
+			specID, _ := specFields(table, data, record)
+			if (ok && record.Content == iceutils.ContentDeletes) || data.Metadata.Content == "deletes" {
+				continue
+			}
+
+			for i := range data.Entries {
+				entry := &data.Entries[i]
+				if !iceutils.IsLive(entry) || iceutils.FileContent(entry) != iceutils.FileContentData {
+					continue
+				}
+				file := &entry.DataFile
+
+				liveUsage(specLive, specID, file)
+				if specID != metadata.DefaultSpecID {
+					resp.OldSpecFiles++
+					resp.OldSpecBytes += file.FileSizeInBytes
+				}
+
+				switch {
+				case file.SortOrderID == nil:
+					resp.UnknownSortOrderFiles++
+				default:
+					liveUsage(orderLive, *file.SortOrderID, file)
+					if *file.SortOrderID != metadata.DefaultSortOrderID {
+						resp.OldSortOrderFiles++
+						resp.OldSortOrderBytes += file.FileSizeInBytes
+					}
+				}
+			}
+		}
+	}
+
+	specs := make(map[int64]*dto.IcebergSpecVersion)
+	for _, spec := range metadata.PartitionSpecs {
+		fields := make([]*dto.OverviewPartitionField, 0, len(spec.Fields))
+		for _, field := range spec.Fields {
+			fields = append(fields, &dto.OverviewPartitionField{
+				Name:      field.Name,
+				Transform: field.Transform,
+				FieldID:   field.FieldID,
+				SourceID:  field.SourceID,
+			})
+		}
+		specs[spec.SpecID] = &dto.IcebergSpecVersion{SpecID: spec.SpecID, Fields: fields}
+	}
+	// the specs only known from the manifest lists, if the metadata dropped them.
+	for id := range specUsages {
+		if specs[id] == nil {
+			specs[id] = &dto.IcebergSpecVersion{SpecID: id, Fields: make([]*dto.OverviewPartitionField, 0)}
+		}
+	}
+
+	for id, spec := range specs {
+		spec.Default = id == metadata.DefaultSpecID
+		spec.Usage = layoutUsage(specUsages[id], timestamps)
+		if live := specLive[id]; live != nil {
+			spec.Usage.LiveDataFiles, spec.Usage.LiveDataBytes, spec.Usage.LiveRecords = live.LiveDataFiles, live.LiveDataBytes, live.LiveRecords
+		}
+		resp.Specs = append(resp.Specs, spec)
+	}
+	slices.SortFunc(resp.Specs, func(a, b *dto.IcebergSpecVersion) int { return cmp.Compare(a.SpecID, b.SpecID) })
+
+	names := make(map[int64]string)
+	for _, schema := range metadata.Schemas {
+		for _, field := range iceutils.FlattenFields(schema.Fields) {
+			names[field.ID] = field.Path
+		}
+	}
+
+	orders := make(map[int64]*dto.IcebergSortOrderVersion)
+	for _, order := range metadata.SortOrders {
+		fields := make([]*dto.IcebergSortField, 0, len(order.Fields))
+		for _, field := range order.Fields {
+			fields = append(fields, &dto.IcebergSortField{
+				Transform:  field.Transform,
+				SourceID:   field.SourceID,
+				SourceName: names[field.SourceID],
+				Direction:  field.Direction,
+				NullOrder:  field.NullOrder,
+			})
+		}
+		orders[order.OrderID] = &dto.IcebergSortOrderVersion{OrderID: order.OrderID, Fields: fields}
+	}
+	for id := range orderUsages {
+		if orders[id] == nil {
+			orders[id] = &dto.IcebergSortOrderVersion{OrderID: id, Fields: make([]*dto.IcebergSortField, 0)}
+		}
+	}
+
+	for id, order := range orders {
+		order.Default = id == metadata.DefaultSortOrderID
+		order.Usage = layoutUsage(orderUsages[id], timestamps)
+		if live := orderLive[id]; live != nil {
+			order.Usage.LiveDataFiles, order.Usage.LiveDataBytes, order.Usage.LiveRecords = live.LiveDataFiles, live.LiveDataBytes, live.LiveRecords
+		}
+		resp.SortOrders = append(resp.SortOrders, order)
+	}
+	slices.SortFunc(resp.SortOrders, func(a, b *dto.IcebergSortOrderVersion) int { return cmp.Compare(a.OrderID, b.OrderID) })
+
+	return resp
+}
+
+// GetLayoutTimeline returns the partition specs and sort orders of the table, with the snapshots that used them
+// and the live data still written with the old ones.
+func (s *IcebergService) GetLayoutTimeline(ctx *gin.Context, userID int64, locid, tableid string) (*dto.IcebergLayoutTimeline, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return layoutTimeline(table), nil
+}
@@ -0,0 +1,235 @@
+package iceberg
+
+import (
+	"cmp"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	iceutils "lakelens/internal/utils/iceberg"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The kinds of orphan files, by their folder under the table root.
+const (
+	orphanData     = "data"
+	orphanMetadata = "metadata"
+	orphanOther    = "other"
+)
+
+// versionHint is the pointer to the current metadata file written by the hadoop catalog, never listed by it.
+const versionHint = "metadata/version-hint.text"
+
+// reachableFiles returns the files reachable from the table metadata, by their paths relative to the table root.
+//
+// Those are the current metadata file and the ones in its metadata-log, the statistics files, and the manifest
+// lists of the retained snapshots with their manifests and the data and delete files of every entry. The deleted
+// entries are kept too, their files may still be read by an older snapshot.
+func reachableFiles(table *dto.Table) map[string]bool {
+
+	metadata := table.Iceberg.Metadata
+	reachable := map[string]bool{versionHint: true}
+	add := func(uri string) {
+		if rel, ok := iceutils.RelativePath(metadata.Location, uri); ok {
+			reachable[rel] = true
+		}
+	}
+
+	// the metadata files are listed, so their paths are keys already.
+	if n := len(table.Iceberg.MetadataFPaths); n != 0 {
+		reachable[strings.TrimPrefix(table.Iceberg.MetadataFPaths[n-1], table.URI)] = true
+	}
+	for _, log := range metadata.MetadataLog {
+		add(log.MetadataFile)
+	}
+	for _, stats := range metadata.Statistics {
+		add(stats.StatisticsPath)
+	}
+	for _, stats := range metadata.PartitionStatistics {
+		add(stats.StatisticsPath)
+	}
+
+	for _, snap := range metadata.Snapshots {
+		add(snap.ManifestList)
+	}
+
+	// the manifests of a snapshot not walked are still reachable, only their entries are unknown.
+	if history := table.Iceberg.History; history != nil {
+		for _, files := range history.Snapshots {
+			for _, record := range files.Manifests {
+				add(record.ManifestPath)
+			}
+		}
+		for manifestPath, data := range history.Manifests {
+			add(manifestPath)
+			for i := range data.Entries {
+				add(data.Entries[i].DataFile.FilePath)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// manifestListsRead reports if the manifest list of every retained snapshot was read, so every manifest of the
+// table is known even if some of them could not be read.
+func manifestListsRead(table *dto.Table) bool {
+
+	history := table.Iceberg.History
+	if history == nil {
+		return false
+	}
+
+	for _, snap := range table.Iceberg.Metadata.Snapshots {
+		files, ok := history.Snapshots[snap.SnapshotID]
+		if !ok || (files.Error != "" && files.Manifests == nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// provableOrphan reports if an unreachable file is an orphan even if the walk was partial.
+//
+// The metadata files are only referenced by the metadata, and the manifest lists and manifests by the snapshots
+// and manifest lists, so those are known once every manifest list is read. The data, delete and any other files
+// may be referenced by the manifests not read.
+func provableOrphan(rel string, listsRead bool) bool {
+
+	if !strings.HasPrefix(rel, orphanMetadata+"/") {
+		return false
+	}
+
+	switch {
+	case strings.HasSuffix(rel, ".metadata.json"):
+		return true
+	case path.Ext(rel) == ".avro":
+		return listsRead
+	}
+
+	return false
+}
+
+// orphanKind returns the kind of an orphan file by its path relative to the table root.
+func orphanKind(rel string) string {
+
+	switch {
+	case strings.HasPrefix(rel, orphanData+"/"):
+		return orphanData
+	case strings.HasPrefix(rel, orphanMetadata+"/"):
+		return orphanMetadata
+	}
+
+	return orphanOther
+}
+
+// tableOrphans returns the listed objects of the table not reachable from any retained snapshot, the largest
+// first. The ones modified less than minAgeMS before the listing are only counted, they may be in-flight writes.
+//
+// If the walk was partial, the files reachable only from the snapshots not walked look unreachable too, so only
+// the provable orphans are reported and the others are counted as withheld.
+func tableOrphans(table *dto.Table, minAgeMS int64) *dto.IcebergOrphans {
+
+	listing := table.Iceberg.Listing
+	reachable := reachableFiles(table)
+	listsRead := manifestListsRead(table)
+
+	resp := &dto.IcebergOrphans{
+		ListedAtMS:    listing.ListedAtMS,
+		MinAgeMS:      minAgeMS,
+		Partial:       table.Iceberg.History == nil || table.Iceberg.History.Partial,
+		ObjectsListed: len(listing.Objects),
+		Orphans:       make([]*dto.IcebergOrphanFile, 0),
+	}
+
+	for _, obj := range listing.Objects {
+		rel := strings.TrimPrefix(obj.Key, table.URI)
+		// the folder markers some tools write, like data/ .
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			continue
+		}
+		if reachable[rel] {
+			resp.ReachableFiles++
+			continue
+		}
+
+		age := listing.ListedAtMS - obj.LastModifiedMS
+		if age < minAgeMS {
+			resp.RecentFiles++
+			resp.RecentBytes += obj.Size
+			continue
+		}
+
+		if resp.Partial && !provableOrphan(rel, listsRead) {
+			resp.WithheldFiles++
+			resp.WithheldBytes += obj.Size
+			continue
+		}
+
+		resp.OrphanFiles++
+		resp.OrphanBytes += obj.Size
+		resp.Orphans = append(resp.Orphans, &dto.IcebergOrphanFile{
+			Key:            obj.Key,
+			Kind:           orphanKind(rel),
+			SizeBytes:      obj.Size,
+			LastModifiedMS: obj.LastModifiedMS,
+			AgeMS:          age,
+		})
+	}
+
+	slices.SortFunc(resp.Orphans, func(a, b *dto.IcebergOrphanFile) int {
+		if c := cmp.Compare(b.SizeBytes, a.SizeBytes); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return resp
+}
+
+// GetOrphans returns a page of the objects under the table root not reachable from any retained snapshot, as
+// listed by the orphan scan.
+func (s *IcebergService) GetOrphans(ctx *gin.Context, userID int64, locid, tableid, offset string) (*dto.IcebergOrphans, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	if table.Iceberg.Listing == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "The table objects were not listed. Please rescan the location with IcebergOrphanScan=true.",
+			ReturnRaw: true,
+		}
+	}
+
+	offSet, err := strconv.Atoi(offset)
+	if err != nil || offSet < 0 {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse offset to a positive int : " + offset,
+		}
+	}
+
+	minAgeMS := int64(configs.Extras.IcebergOrphanMinAgeHours) * 3600 * 1000
+
+	return orphansPage(tableOrphans(table, minAgeMS), offSet, configs.Extras.IcebergOrphansPageSize), nil
+}
+
+func orphansPage(resp *dto.IcebergOrphans, offset, limit int) *dto.IcebergOrphans {
+
+	resp.Offset = offset
+	resp.Limit = limit
+
+	start := min(offset, len(resp.Orphans))
+	end := min(start+max(limit, 0), len(resp.Orphans))
+	resp.Orphans = resp.Orphans[start:end]
+
+	return resp
+}
@@ -0,0 +1,210 @@
+package iceberg
+
+import (
+	"cmp"
+	"fmt"
+	configs "lakelens/internal/config"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// specFields returns the fields of the partition spec of the given manifest, from the table metadata or else
+// from the manifest itself.
+func specFields(table *dto.Table, data *formats.ManifestData, record *formats.SnapshotRecord) (int64, []formats.IcebergPartitionSpecField) {
+
+	specID, err := strconv.ParseInt(data.Metadata.PartitionSpecID, 10, 64)
+	if record != nil {
+		specID, err = int64(record.PartitionSpecID), nil
+	}
+
+	if err == nil {
+		for _, spec := range table.Iceberg.Metadata.PartitionSpecs {
+			if spec.SpecID == specID {
+				return specID, spec.Fields
+			}
+		}
+	}
+
+	return specID, data.Metadata.PartitionSpec
+}
+
+// partitionValues decodes the partition tuple of a file, in the order of the spec fields.
+func partitionValues(partition map[string]any, fields []formats.IcebergPartitionSpecField, types map[int64]string) ([]*dto.IcebergPartitionValue, string) {
+
+	values := make([]*dto.IcebergPartitionValue, 0, len(fields))
+	pairs := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		value := &dto.IcebergPartitionValue{
+			Name:      field.Name,
+			Transform: field.Transform,
+			SourceID:  field.SourceID,
+			Value:     iceutils.PartitionValue(field.Transform, types[field.SourceID], partition[field.Name]),
+		}
+		values = append(values, value)
+		pairs = append(pairs, value.Name+"="+value.Value)
+	}
+
+	return values, strings.Join(pairs, "/")
+}
+
+// tablePartitions aggregates the files of the current snapshot per partition, the hottest first.
+func tablePartitions(table *dto.Table, hotSkew float64) *dto.IcebergPartitions {
+
+	metadata := table.Iceberg.Metadata
+
+	resp := &dto.IcebergPartitions{
+		SnapshotID: metadata.CurrentSnapshotID,
+		Partitions: make([]*dto.IcebergPartitionStats, 0),
+	}
+
+	if len(table.Iceberg.Manifest) == 0 {
+		return resp
+	}
+
+	records := make(map[string]*formats.SnapshotRecord)
+	if len(table.Iceberg.Snapshot) != 0 {
+		for _, record := range table.Iceberg.Snapshot[0].Records {
+			records[record.ManifestPath] = record
+		}
+	}
+
+	timestamps := make(map[int64]int64, len(metadata.Snapshots))
+	for _, snap := range metadata.Snapshots {
+		timestamps[snap.SnapshotID] = snap.TimestampMS
+	}
+
+	types := iceutils.SourceTypes(metadata.Schemas)
+	parts := make(map[string]*dto.IcebergPartitionStats)
+
+	for _, data := range table.Iceberg.Manifest[0].Data {
+		record, ok := records[data.URI]
+		specID, fields := specFields(table, data, record)
+		if !ok {
+			// the entries without their own snapshot id get none.
+			record = &formats.SnapshotRecord{}
+		}
+
+		for i := range data.Entries {
+			entry := &data.Entries[i]
+
+			key := fmt.Sprintf("%d/%s", specID, iceutils.PartitionKey(entry.DataFile.Partition))
+			part, ok := parts[key]
+			if !ok {
+				part = &dto.IcebergPartitionStats{SpecID: specID}
+				part.Values, part.Partition = partitionValues(entry.DataFile.Partition, fields, types)
+				parts[key] = part
+			}
+
+			snapID := iceutils.EntrySnapshotID(entry, record)
+			if entry.Status != iceutils.StatusExisting && (part.LastSnapshotID == 0 || timestamps[snapID] > part.LastModifiedMS) {
+				part.LastSnapshotID = snapID
+				part.LastModifiedMS = timestamps[snapID]
+			}
+
+			if !iceutils.IsLive(entry) {
+				continue
+			}
+
+			if iceutils.FileContent(entry) == iceutils.FileContentData {
+				part.DataFiles++
+				part.Records += entry.DataFile.RecordCount
+				part.Bytes += entry.DataFile.FileSizeInBytes
+			} else {
+				part.DeleteFiles++
+				part.DeleteRecords += entry.DataFile.RecordCount
+			}
+		}
+	}
+
+	for _, part := range parts {
+		// the partitions emptied by the current snapshot are gone.
+		if part.DataFiles != 0 || part.DeleteFiles != 0 {
+			resp.Partitions = append(resp.Partitions, part)
+		}
+	}
+	resp.TotalPartitions = len(resp.Partitions)
+
+	rateSkew(resp, hotSkew)
+
+	slices.SortFunc(resp.Partitions, func(a, b *dto.IcebergPartitionStats) int {
+		if c := cmp.Compare(b.Records, a.Records); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Partition, b.Partition)
+	})
+
+	return resp
+}
+
+// rateSkew sets the skew of every partition, and the skew score of the table as the coefficient of variation
+// of the records per partition.
+func rateSkew(resp *dto.IcebergPartitions, hotSkew float64) {
+
+	if len(resp.Partitions) == 0 {
+		return
+	}
+
+	var total float64
+	for _, part := range resp.Partitions {
+		total += float64(part.Records)
+	}
+	mean := total / float64(len(resp.Partitions))
+	resp.MeanRecords = mean
+
+	if mean == 0 {
+		return
+	}
+
+	var variance float64
+	for _, part := range resp.Partitions {
+		part.Skew = float64(part.Records) / mean
+		// a single partition is never hot.
+		if len(resp.Partitions) > 1 && part.Skew >= hotSkew {
+			part.Hot = true
+			resp.HotPartitions++
+		}
+		variance += (float64(part.Records) - mean) * (float64(part.Records) - mean)
+	}
+
+	resp.SkewScore = math.Sqrt(variance/float64(len(resp.Partitions))) / mean
+}
+
+// GetPartitions returns a page of the partitions of the current snapshot, starting at the given offset.
+func (s *IcebergService) GetPartitions(ctx *gin.Context, userID int64, locid, tableid, offset string) (*dto.IcebergPartitions, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	offSet, err := strconv.Atoi(offset)
+	if err != nil || offSet < 0 {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInvalidInput,
+			Message: "Failed to parse offset to a positive int : " + offset,
+		}
+	}
+
+	return partitionsPage(tablePartitions(table, configs.Extras.IcebergHotPartitionSkew), offSet, configs.Extras.IcebergPartitionsPageSize), nil
+}
+
+func partitionsPage(resp *dto.IcebergPartitions, offset, limit int) *dto.IcebergPartitions {
+
+	resp.Offset = offset
+	resp.Limit = limit
+
+	start := min(offset, len(resp.Partitions))
+	end := min(start+max(limit, 0), len(resp.Partitions))
+	resp.Partitions = resp.Partitions[start:end]
+
+	return resp
+}
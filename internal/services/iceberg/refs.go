@@ -0,0 +1,169 @@
+package iceberg
+
+import (
+	"cmp"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// refInfo returns the given ref with its retention and ancestry, relative to the main branch.
+func refInfo(metadata *formats.IcebergMetadata, name string, ref formats.IcebergRef, mainAncestry []int64, nowMS int64) *dto.IcebergRef {
+
+	retention := iceutils.RefRetention(name, ref, metadata.Properties)
+
+	info := &dto.IcebergRef{
+		Name:                        name,
+		Type:                        ref.Type,
+		SnapshotID:                  ref.SnapshotID,
+		SchemaID:                    metadata.CurrentSchemaID,
+		MaxRefAgeMS:                 ref.MaxRefAgeMS,
+		MinSnapshotsToKeep:          ref.MinSnapshotsToKeep,
+		MaxSnapshotAgeMS:            ref.MaxSnapshotAgeMS,
+		EffectiveMaxRefAgeMS:        retention.MaxRefAgeMS,
+		EffectiveMinSnapshotsToKeep: retention.MinSnapshotsToKeep,
+		EffectiveMaxSnapshotAgeMS:   retention.MaxSnapshotAgeMS,
+	}
+
+	for _, snap := range metadata.Snapshots {
+		if snap.SnapshotID == ref.SnapshotID {
+			info.TimestampMS = snap.TimestampMS
+			if ref.Type == iceutils.RefTag {
+				info.SchemaID = snap.SchemaID
+			}
+			break
+		}
+	}
+
+	if info.TimestampMS != 0 && retention.MaxRefAgeMS > 0 {
+		info.Expired = nowMS-info.TimestampMS > retention.MaxRefAgeMS
+	}
+
+	ancestry := iceutils.Ancestry(metadata, ref.SnapshotID)
+	info.Snapshots = len(ancestry)
+
+	inMain := make(map[int64]bool, len(mainAncestry))
+	for _, id := range mainAncestry {
+		inMain[id] = true
+	}
+	inRef := make(map[int64]bool, len(ancestry))
+	for _, id := range ancestry {
+		inRef[id] = true
+		if !inMain[id] {
+			info.AheadOfMain++
+		}
+	}
+	for _, id := range mainAncestry {
+		if !inRef[id] {
+			info.BehindMain++
+		}
+	}
+
+	return info
+}
+
+// tableRefs returns the refs of the table, main first and then by name.
+func tableRefs(table *dto.Table, nowMS int64) []*dto.IcebergRef {
+
+	metadata := table.Iceberg.Metadata
+	refs := iceutils.TableRefs(metadata)
+
+	var mainAncestry []int64
+	if main, ok := refs[iceutils.MainBranch]; ok {
+		mainAncestry = iceutils.Ancestry(metadata, main.SnapshotID)
+	}
+
+	infos := make([]*dto.IcebergRef, 0, len(refs))
+	for name, ref := range refs {
+		infos = append(infos, refInfo(metadata, name, ref, mainAncestry, nowMS))
+	}
+
+	slices.SortFunc(infos, func(a, b *dto.IcebergRef) int {
+		switch {
+		case a.Name == iceutils.MainBranch:
+			return -1
+		case b.Name == iceutils.MainBranch:
+			return 1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return infos
+}
+
+func (s *IcebergService) GetRefs(ctx *gin.Context, userID int64, locid, tableid string) (*dto.IcebergRefs, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &dto.IcebergRefs{
+		CurrentSnapshotID: table.Iceberg.Metadata.CurrentSnapshotID,
+		Refs:              tableRefs(table, time.Now().UnixMilli()),
+	}, nil
+}
+
+// GetRefOverview returns the overview stats and schema of the table at the given branch or tag.
+func (s *IcebergService) GetRefOverview(ctx *gin.Context, userID int64, locid, tableid, refname string) (*dto.IcebergRefOverview, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return refOverview(table, refname, time.Now().UnixMilli())
+}
+
+func refOverview(table *dto.Table, refname string, nowMS int64) (*dto.IcebergRefOverview, *errs.Errorf) {
+
+	var ref *dto.IcebergRef
+	for _, info := range tableRefs(table, nowMS) {
+		if info.Name == refname {
+			ref = info
+			break
+		}
+	}
+	if ref == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "Ref " + refname + " not found in the table.",
+			ReturnRaw: true,
+		}
+	}
+
+	var snap *formats.IcebergMetadataSnapshot
+	for i := range table.Iceberg.Metadata.Snapshots {
+		if table.Iceberg.Metadata.Snapshots[i].SnapshotID == ref.SnapshotID {
+			snap = &table.Iceberg.Metadata.Snapshots[i]
+			break
+		}
+	}
+	if snap == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "The snapshot of ref " + refname + " is not in the table metadata.",
+			ReturnRaw: true,
+		}
+	}
+
+	schema := findSchemaByID(table, ref.SchemaID)
+	if schema == nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrNotFound,
+			Message:   "The schema of ref " + refname + " is not in the table metadata.",
+			ReturnRaw: true,
+		}
+	}
+
+	return &dto.IcebergRefOverview{
+		Ref:    ref,
+		Stats:  overviewStats(table, snap),
+		Schema: overviewSchema(schema),
+	}, nil
+}
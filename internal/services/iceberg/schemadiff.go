@@ -0,0 +1,272 @@
+package iceberg
+
+import (
+	"fmt"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	formats "lakelens/internal/dto/formats/iceberg"
+	iceutils "lakelens/internal/utils/iceberg"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The field changes between two schemas.
+const (
+	changeAdded        = "added"
+	changeDropped      = "dropped"
+	changeRenamed      = "renamed"
+	changeTypePromoted = "type_promoted"
+	changeTypeChanged  = "type_changed"
+	changeRequired     = "required"
+	changeReordered    = "reordered"
+	changeMoved        = "moved"
+)
+
+// diffSchemas returns the field changes from one schema to the other, fields are matched by id and nested
+// fields named by their dotted paths. Each change is checked against the schema evolution rules of the spec.
+func diffSchemas(from, to *formats.IcebergSchema) []*dto.IcebergFieldChange {
+
+	changes := make([]*dto.IcebergFieldChange, 0)
+
+	fromFlat := iceutils.FlattenFields(from.Fields)
+	toFlat := iceutils.FlattenFields(to.Fields)
+
+	fromFields := make(map[int64]*iceutils.FlatField)
+	for _, field := range fromFlat {
+		fromFields[field.ID] = field
+	}
+	toFields := make(map[int64]*iceutils.FlatField)
+	for _, field := range toFlat {
+		toFields[field.ID] = field
+	}
+
+	for _, field := range fromFlat {
+		if _, ok := toFields[field.ID]; !ok {
+			changes = append(changes, &dto.IcebergFieldChange{
+				ID:     field.ID,
+				Name:   field.Path,
+				Change: changeDropped,
+				From:   field.Type.String(),
+				Legal:  true,
+			})
+		}
+	}
+
+	reordered := reorderedFields(fromFlat, toFlat)
+
+	for _, field := range toFlat {
+		old, ok := fromFields[field.ID]
+		if !ok {
+			change := &dto.IcebergFieldChange{
+				ID:     field.ID,
+				Name:   field.Path,
+				Change: changeAdded,
+				To:     field.Type.String(),
+				Legal:  true,
+			}
+			if field.Required && field.InitialDefault == nil {
+				change.Legal = false
+				change.Reason = "A required field can only be added with an initial default."
+			}
+			changes = append(changes, change)
+			continue
+		}
+
+		if old.Name != field.Name {
+			changes = append(changes, &dto.IcebergFieldChange{ID: field.ID, Name: field.Path, Change: changeRenamed, From: old.Path, To: field.Path, Legal: true})
+		}
+
+		if old.ParentID != field.ParentID {
+			changes = append(changes, &dto.IcebergFieldChange{
+				ID:     field.ID,
+				Name:   field.Path,
+				Change: changeMoved,
+				From:   old.Path,
+				To:     field.Path,
+				Reason: "A field can not be moved to another struct.",
+			})
+		} else if reordered[field.ID] {
+			changes = append(changes, &dto.IcebergFieldChange{ID: field.ID, Name: field.Path, Change: changeReordered, From: old.Path, To: field.Path, Legal: true})
+		}
+
+		if change := typeChange(old, field); change != nil {
+			changes = append(changes, change)
+		}
+
+		if old.Required != field.Required {
+			change := &dto.IcebergFieldChange{
+				ID:     field.ID,
+				Name:   field.Path,
+				Change: changeRequired,
+				From:   requiredName(old.Required),
+				To:     requiredName(field.Required),
+				Legal:  !field.Required,
+			}
+			if field.Required {
+				change.Reason = "An optional field can not be made required."
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// typeChange returns the type change of the field, nil if its type did not change. The nested types only
+// change when their kind changes, their fields are compared on their own.
+func typeChange(old, field *iceutils.FlatField) *dto.IcebergFieldChange {
+
+	if old.Type.Nested == field.Type.Nested && old.Type.Primitive == field.Type.Primitive {
+		return nil
+	}
+
+	change := &dto.IcebergFieldChange{
+		ID:     field.ID,
+		Name:   field.Path,
+		Change: changeTypeChanged,
+		From:   old.Type.String(),
+		To:     field.Type.String(),
+	}
+
+	switch {
+	case old.Type.Nested != "" || field.Type.Nested != "":
+		change.Reason = fmt.Sprintf("A %s field can not be changed to a %s.", typeKind(old.Type), typeKind(field.Type))
+	case iceutils.PromotionAllowed(old.Type.Primitive, field.Type.Primitive):
+		change.Change = changeTypePromoted
+		change.Legal = true
+	default:
+		change.Reason = fmt.Sprintf("The %s type can not be promoted to %s.", old.Type.Primitive, field.Type.Primitive)
+	}
+
+	return change
+}
+
+func typeKind(typ *formats.IcebergType) string {
+
+	if typ.Nested != "" {
+		return typ.Nested
+	}
+
+	return "primitive"
+}
+
+func requiredName(required bool) string {
+
+	if required {
+		return "required"
+	}
+
+	return "optional"
+}
+
+// reorderedFields returns the ids of the fields whose position changed among their siblings kept in both
+// schemas. The fields kept in order are the longest run already ordered, the others are the ones moved.
+func reorderedFields(fromFlat, toFlat []*iceutils.FlatField) map[int64]bool {
+
+	toPos := make(map[int64]int)
+	toParent := make(map[int64]int64)
+	for i, field := range toFlat {
+		toPos[field.ID] = i
+		toParent[field.ID] = field.ParentID
+	}
+
+	// parent id to the kept children, in their old order.
+	siblings := make(map[int64][]int64)
+	for _, field := range fromFlat {
+		if parent, ok := toParent[field.ID]; ok && parent == field.ParentID {
+			siblings[field.ParentID] = append(siblings[field.ParentID], field.ID)
+		}
+	}
+
+	reordered := make(map[int64]bool)
+	for _, ids := range siblings {
+		kept := longestOrdered(ids, toPos)
+		for _, id := range ids {
+			if !kept[id] {
+				reordered[id] = true
+			}
+		}
+	}
+
+	return reordered
+}
+
+// longestOrdered returns the longest subsequence of the ids whose new positions are increasing.
+func longestOrdered(ids []int64, pos map[int64]int) map[int64]bool {
+
+	lengths := make([]int, len(ids))
+	prev := make([]int, len(ids))
+
+	best := -1
+	for i := range ids {
+		lengths[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if pos[ids[j]] < pos[ids[i]] && lengths[j]+1 > lengths[i] {
+				lengths[i], prev[i] = lengths[j]+1, j
+			}
+		}
+		if best < 0 || lengths[i] > lengths[best] {
+			best = i
+		}
+	}
+
+	kept := make(map[int64]bool)
+	for i := best; i >= 0; i = prev[i] {
+		kept[ids[i]] = true
+	}
+
+	return kept
+}
+
+func (s *IcebergService) GetSchemaDiff(ctx *gin.Context, userID int64, locid, tableid, from, to string) (*dto.IcebergSchemaDiff, *errs.Errorf) {
+
+	table, errf := s.fetchTable(ctx, userID, locid, tableid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return schemaDiff(table, from, to)
+}
+
+// schemaDiff diffs the schemas of the given ids, fields are matched by their field ids.
+func schemaDiff(table *dto.Table, from, to string) (*dto.IcebergSchemaDiff, *errs.Errorf) {
+
+	schemas := make([]*formats.IcebergSchema, 0, 2)
+	for _, schemaid := range []string{from, to} {
+		schemaID, err := strconv.ParseInt(schemaid, 10, 64)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrInvalidInput,
+				Message: "Failed to parse schema id to int64 : " + err.Error(),
+			}
+		}
+
+		schema := findSchemaByID(table, schemaID)
+		if schema == nil {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrNotFound,
+				Message:   fmt.Sprintf("Schema %d not found in the table.", schemaID),
+				ReturnRaw: true,
+			}
+		}
+		schemas = append(schemas, schema)
+	}
+
+	resp := &dto.IcebergSchemaDiff{
+		FromSchemaID: schemas[0].SchemaID,
+		ToSchemaID:   schemas[1].SchemaID,
+		Legal:        true,
+		Counts:       make(map[string]int),
+		Changes:      diffSchemas(schemas[0], schemas[1]),
+	}
+
+	for _, change := range resp.Changes {
+		resp.Counts[change.Change]++
+		if !change.Legal {
+			resp.Legal = false
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,213 @@
+package iceberg
+
+import (
+	"encoding/json"
+	"fmt"
+	formats "lakelens/internal/dto/formats/iceberg"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func testSchema(t *testing.T, fields string) *formats.IcebergSchema {
+	t.Helper()
+
+	schema := new(formats.IcebergSchema)
+	if err := json.Unmarshal([]byte(`{"type":"struct","schema-id":0,"fields":`+fields+`}`), schema); err != nil {
+		t.Fatalf("invalid test schema : %v", err)
+	}
+
+	return schema
+}
+
+func TestDiffSchemas(t *testing.T) {
+
+	base := `[
+		{"id":1,"name":"id","required":true,"type":"long"},
+		{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"},
+		{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+			{"id":4,"name":"city","required":false,"type":"string"},
+			{"id":5,"name":"zip","required":false,"type":"int"}
+		]}}
+	]`
+
+	tests := []struct {
+		name string
+		to   string
+		want []string // id change legal, in the order of the changes.
+	}{
+		{
+			name: "unchanged",
+			to:   base,
+			want: []string{},
+		},
+		{
+			name: "optional field added",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"},
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"int"},
+					{"id":6,"name":"country","required":false,"type":"string"}
+				]}}
+			]`,
+			want: []string{"6 added true"},
+		},
+		{
+			name: "required field added without and with a default",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"},
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"int"}
+				]}},
+				{"id":6,"name":"a","required":true,"type":"string"},
+				{"id":7,"name":"b","required":true,"type":"string","initial-default":"x"}
+			]`,
+			want: []string{"6 added false", "7 added true"},
+		},
+		{
+			name: "nested field dropped and renamed",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"},
+				{"id":3,"name":"addr","required":false,"type":{"type":"struct","fields":[
+					{"id":5,"name":"zip","required":false,"type":"int"}
+				]}}
+			]`,
+			want: []string{"4 dropped true", "3 renamed true"},
+		},
+		{
+			name: "legal promotions",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(12, 2)"},
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"long"}
+				]}}
+			]`,
+			want: []string{"2 type_promoted true", "5 type_promoted true"},
+		},
+		{
+			name: "illegal type changes",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"int"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(12, 3)"},
+				{"id":3,"name":"address","required":false,"type":"string"}
+			]`,
+			want: []string{"4 dropped true", "5 dropped true", "1 type_changed false", "2 type_changed false", "3 type_changed false"},
+		},
+		{
+			name: "required changes",
+			to: `[
+				{"id":1,"name":"id","required":false,"type":"long"},
+				{"id":2,"name":"amount","required":true,"type":"decimal(10, 2)"},
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"int"}
+				]}}
+			]`,
+			want: []string{"1 required true", "2 required false"},
+		},
+		{
+			name: "field reordered",
+			to: `[
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"int"}
+				]}},
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"}
+			]`,
+			want: []string{"3 reordered true"},
+		},
+		{
+			name: "field moved into a struct",
+			to: `[
+				{"id":1,"name":"id","required":true,"type":"long"},
+				{"id":3,"name":"address","required":false,"type":{"type":"struct","fields":[
+					{"id":4,"name":"city","required":false,"type":"string"},
+					{"id":5,"name":"zip","required":false,"type":"int"},
+					{"id":2,"name":"amount","required":false,"type":"decimal(10, 2)"}
+				]}}
+			]`,
+			want: []string{"2 moved false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := diffSchemas(testSchema(t, base), testSchema(t, tt.to))
+
+			got := make([]string, 0, len(changes))
+			for _, change := range changes {
+				got = append(got, fmt.Sprintf("%d %s %v", change.ID, change.Change, change.Legal))
+				if !change.Legal && change.Reason == "" {
+					t.Errorf("illegal change %d %s has no reason", change.ID, change.Change)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("diffSchemas() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLongestOrdered(t *testing.T) {
+
+	tests := []struct {
+		name string
+		ids  []int64
+		pos  map[int64]int
+		want []int64
+	}{
+		{
+			name: "empty",
+			ids:  []int64{},
+			pos:  map[int64]int{},
+			want: []int64{},
+		},
+		{
+			name: "already ordered",
+			ids:  []int64{1, 2, 3},
+			pos:  map[int64]int{1: 0, 2: 1, 3: 2},
+			want: []int64{1, 2, 3},
+		},
+		{
+			name: "last moved to the front",
+			ids:  []int64{1, 2, 3},
+			pos:  map[int64]int{1: 1, 2: 2, 3: 0},
+			want: []int64{1, 2},
+		},
+		{
+			name: "first moved to the end",
+			ids:  []int64{1, 2, 3, 4},
+			pos:  map[int64]int{1: 3, 2: 0, 3: 1, 4: 2},
+			want: []int64{2, 3, 4},
+		},
+		{
+			name: "reversed keeps one",
+			ids:  []int64{1, 2, 3},
+			pos:  map[int64]int{1: 2, 2: 1, 3: 0},
+			want: []int64{1},
+		},
+		{
+			name: "positions with gaps",
+			ids:  []int64{5, 6, 7, 8},
+			pos:  map[int64]int{5: 10, 6: 2, 7: 11, 8: 12},
+			want: []int64{5, 7, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Sorted(maps.Keys(longestOrdered(tt.ids, tt.pos)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("longestOrdered(%v) = %v, want %v", tt.ids, got, tt.want)
+			}
+		})
+	}
+}
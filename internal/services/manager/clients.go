@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"lakelens/internal/adapters/objstore"
+	"lakelens/internal/consts"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CloudClient is implemented by every provider type, all manager flows go through it.
+type CloudClient interface {
+	GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf)
+	// AddLocs validates the locations before adding, locNames are bucket names or bucket/prefix/ pairs.
+	// A prefix is validated with a list scoped to it, so no access to the bucket root is needed.
+	AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf)
+	// ProcessLoc scrapes a single location, prefix is empty for the whole bucket.
+	// The requests count against the limiter, share one between the locations of a lake scanned at once.
+	ProcessLoc(ctx *gin.Context, bucName, prefix string, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf)
+
+	// CheckLoc runs the auth/read/write checks for a single location, confined to the prefix if any.
+	CheckLoc(ctx *gin.Context, bucName, prefix string) (*dto.LocCheckResp, *errs.Errorf)
+	// LocFileDist adds the file extension distribution of a location to distMp, confined to the prefix if any.
+	LocFileDist(ctx *gin.Context, bucName, prefix string, distMp map[string]*dto.LakeFileDistStats) *errs.Errorf
+}
+
+// getCloudClient returns the CloudClient for the given lake depending on its provider type.
+func (s *ManagerService) getCloudClient(ctx *gin.Context, lakeID int64, ptype string) (CloudClient, *errs.Errorf) {
+
+	switch ptype {
+	case consts.AWSS3, consts.MinIO:
+		s3Client, err := s.Stash.GetS3Client(ctx, lakeID)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get s3 client : " + err.Error(),
+			}
+		}
+		return &S3Client{
+			client: s3Client,
+			ptype:  ptype,
+		}, nil
+	case consts.Azure:
+		azClient, err := s.Stash.GetAzureClient(ctx, lakeID)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get azure client : " + err.Error(),
+			}
+		}
+		return &AzureClient{
+			client: azClient,
+		}, nil
+	case consts.GCS:
+		gcsClient, projectID, err := s.Stash.GetGCSClient(ctx, lakeID)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get gcs client : " + err.Error(),
+			}
+		}
+		return &GCSClient{
+			client:    gcsClient,
+			projectID: projectID,
+		}, nil
+	case consts.Local:
+		root, err := s.Stash.GetLocalClient(ctx, lakeID)
+		if err != nil {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get local client : " + err.Error(),
+			}
+		}
+		return &LocalClient{
+			root: root,
+		}, nil
+	default:
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "The lake provider is not supported : " + ptype,
+			ReturnRaw: true,
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	azengine "lakelens/internal/adapters/azure/engine"
+	"lakelens/internal/adapters/engine"
+	"lakelens/internal/adapters/objstore"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/gin-gonic/gin"
+)
+
+// AzureClient is the CloudClient for Azure Blob Storage and ADLS Gen2 accounts, containers are the locations.
+type AzureClient struct {
+	client *azblob.Client
+}
+
+func (c *AzureClient) GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf) {
+
+	containers, err := azengine.ListContainers(ctx, c.client)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDependencyFailed,
+			Message: "Failed to list containers from azure : " + err.Error(),
+		}
+	}
+
+	locs := make([]*dto.Locations, 0)
+	for _, cont := range containers {
+		locs = append(locs, &dto.Locations{
+			Name:         &cont.Name,
+			CreationDate: cont.CreationDate,
+			Region:       cont.Region,
+		})
+	}
+
+	return locs, nil
+}
+
+func (c *AzureClient) AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf) {
+
+	resp := new(dto.AddLocsResp)
+
+	for _, locName := range locNames {
+
+		contName, prefix, _ := utils.ParseLocName(locName)
+		contClient := c.client.ServiceClient().NewContainerClient(contName)
+
+		if prefix != "" {
+			// a prefix is validated by listing inside it, the container root might not be accessible.
+			oneObj := int32(1)
+			page, err := contClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+				Prefix:     &prefix,
+				MaxResults: &oneObj,
+			}).NextPage(ctx)
+			if err != nil {
+				if isAzureClientErr(err) {
+					resp.Failed = append(resp.Failed, locName)
+					continue
+				}
+				return nil, &errs.Errorf{
+					Type:    errs.ErrDependencyFailed,
+					Message: "Failed to list prefix to add location : " + err.Error(),
+				}
+			}
+			if page.Segment == nil || len(page.Segment.BlobItems) == 0 {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+
+			resp.Added = append(resp.Added, locName)
+			continue
+		}
+
+		_, err := contClient.GetProperties(ctx, nil)
+		if err != nil {
+			if isAzureClientErr(err) {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get container properties to add location : " + err.Error(),
+			}
+		}
+
+		resp.Added = append(resp.Added, locName)
+	}
+
+	return resp, nil
+}
+
+func (c *AzureClient) ProcessLoc(ctx *gin.Context, bucName, prefix string, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf) {
+
+	cont := &dto.BucketData{
+		Name:   bucName,
+		Prefix: prefix,
+	}
+
+	// container properties need access to the container root, which prefix scoped credentials usually lack.
+	if prefix == "" {
+		var errf *errs.Errorf
+		cont, errf = azengine.GetContainer(ctx, c.client, bucName)
+		if errf != nil {
+			return nil, errf
+		}
+	}
+
+	newBucket, errf := engine.ScrapeLoc(ctx, objstore.NewAzureStore(c.client, bucName), cont, limiter, opts)
+	if errf != nil {
+		return newBucket, errf
+	}
+
+	return newBucket, nil
+}
+
+func (c *AzureClient) CheckLoc(ctx *gin.Context, bucName, prefix string) (*dto.LocCheckResp, *errs.Errorf) {
+
+	check := new(dto.LocCheckResp)
+	check.BucketName = bucName
+	check.Prefix = prefix
+
+	contClient := c.client.ServiceClient().NewContainerClient(bucName)
+
+	oneObj := int32(1)
+	_, err := contClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix:     &prefix,
+		MaxResults: &oneObj,
+	}).NextPage(ctx)
+	if err != nil {
+		if !isAzureClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get one blob to determine read check : " + err.Error(),
+			}
+		}
+		check.ReadCheck = false
+	} else {
+		check.ReadCheck = true
+	}
+
+	writeKey := prefix + fmt.Sprintf("%s.%d", "temp_obj_lakelens", time.Now().Unix())
+	_, err = contClient.NewBlockBlobClient(writeKey).UploadBuffer(ctx, []byte{}, nil)
+	if err != nil {
+		if !isAzureClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to put temp blob to determine write check : " + err.Error(),
+			}
+		}
+		check.WriteCheck = false
+	} else {
+		check.WriteCheck = true
+	}
+
+	// the container root is never touched for a prefix, being able to list it is the auth check.
+	if prefix != "" {
+		check.AuthCheck = check.ReadCheck
+		return check, nil
+	}
+
+	_, err = contClient.GetProperties(ctx, nil)
+	if err != nil {
+		if !isAzureClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get container properties to determine auth check : " + err.Error(),
+			}
+		}
+		check.AuthCheck = false
+	} else {
+		check.AuthCheck = true
+	}
+
+	return check, nil
+}
+
+func (c *AzureClient) LocFileDist(ctx *gin.Context, bucName, prefix string, distMp map[string]*dto.LakeFileDistStats) *errs.Errorf {
+
+	pager := c.client.ServiceClient().NewContainerClient(bucName).NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to list azure blobs : " + err.Error(),
+			}
+		}
+		if page.Segment == nil {
+			break
+		}
+
+		for _, b := range page.Segment.BlobItems {
+			if b.Name == nil {
+				continue
+			}
+			if ext := path.Ext(*b.Name); ext != "" {
+				if distMp[ext] == nil {
+					distMp[ext] = &dto.LakeFileDistStats{}
+				}
+
+				if b.Properties != nil && b.Properties.ContentLength != nil {
+					distMp[ext].TotalSize += *b.Properties.ContentLength
+				}
+				distMp[ext].FileCount += 1
+			}
+		}
+	}
+
+	return nil
+}
+
+// isAzureClientErr reports whether err is a 4xx response from azure, i.e. the request itself was denied/invalid.
+func isAzureClientErr(err error) bool {
+	var respErr *azcore.ResponseError
+	if errors.As(err, &respErr) && (respErr.StatusCode >= 400 && respErr.StatusCode < 500) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"lakelens/internal/adapters/engine"
+	gcsengine "lakelens/internal/adapters/gcs/engine"
+	"lakelens/internal/adapters/objstore"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"path"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// GCSClient is the CloudClient for Google Cloud Storage, buckets of the project are the locations.
+type GCSClient struct {
+	client    *storage.Client
+	projectID string
+}
+
+func (c *GCSClient) GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf) {
+
+	bucs, err := gcsengine.ListBuckets(ctx, c.client, c.projectID)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDependencyFailed,
+			Message: "Failed to list buckets from gcs : " + err.Error(),
+		}
+	}
+
+	locs := make([]*dto.Locations, 0)
+	for _, buc := range bucs {
+		locs = append(locs, &dto.Locations{
+			Name:         &buc.Name,
+			CreationDate: buc.CreationDate,
+			Region:       buc.Region,
+		})
+	}
+
+	return locs, nil
+}
+
+func (c *GCSClient) AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf) {
+
+	resp := new(dto.AddLocsResp)
+
+	for _, locName := range locNames {
+
+		bucName, prefix, _ := utils.ParseLocName(locName)
+		if prefix != "" {
+			// a prefix is validated by listing inside it, the bucket root might not be accessible.
+			_, err := c.client.Bucket(bucName).Objects(ctx, &storage.Query{Prefix: prefix}).Next()
+			if err == iterator.Done {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+			if err != nil {
+				if isGCSClientErr(err) {
+					resp.Failed = append(resp.Failed, locName)
+					continue
+				}
+				return nil, &errs.Errorf{
+					Type:    errs.ErrDependencyFailed,
+					Message: "Failed to list prefix to add location : " + err.Error(),
+				}
+			}
+
+			resp.Added = append(resp.Added, locName)
+			continue
+		}
+
+		_, err := c.client.Bucket(bucName).Attrs(ctx)
+		if err != nil {
+			if isGCSClientErr(err) {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get bucket attributes to add location : " + err.Error(),
+			}
+		}
+
+		resp.Added = append(resp.Added, locName)
+	}
+
+	return resp, nil
+}
+
+func (c *GCSClient) ProcessLoc(ctx *gin.Context, bucName, prefix string, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf) {
+
+	bucket := &dto.BucketData{
+		Name:   bucName,
+		Prefix: prefix,
+	}
+
+	// bucket attributes need access to the bucket root, which prefix scoped credentials usually lack.
+	if prefix == "" {
+		var errf *errs.Errorf
+		bucket, errf = gcsengine.GetBucket(ctx, c.client, bucName)
+		if errf != nil {
+			return nil, errf
+		}
+	}
+
+	newBucket, errf := engine.ScrapeLoc(ctx, objstore.NewGCSStore(c.client, bucName), bucket, limiter, opts)
+	if errf != nil {
+		return newBucket, errf
+	}
+
+	return newBucket, nil
+}
+
+func (c *GCSClient) CheckLoc(ctx *gin.Context, bucName, prefix string) (*dto.LocCheckResp, *errs.Errorf) {
+
+	check := new(dto.LocCheckResp)
+	check.BucketName = bucName
+	check.Prefix = prefix
+
+	bucket := c.client.Bucket(bucName)
+
+	_, err := bucket.Objects(ctx, &storage.Query{Prefix: prefix}).Next()
+	if err != nil && err != iterator.Done {
+		if !isGCSClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get one object to determine read check : " + err.Error(),
+			}
+		}
+		check.ReadCheck = false
+	} else {
+		check.ReadCheck = true
+	}
+
+	writeKey := prefix + fmt.Sprintf("%s.%d", "temp_obj_lakelens", time.Now().Unix())
+	writer := bucket.Object(writeKey).NewWriter(ctx)
+	err = writer.Close()
+	if err != nil {
+		if !isGCSClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to put temp object to determine write check : " + err.Error(),
+			}
+		}
+		check.WriteCheck = false
+	} else {
+		check.WriteCheck = true
+	}
+
+	// the bucket root is never touched for a prefix, being able to list it is the auth check.
+	if prefix != "" {
+		check.AuthCheck = check.ReadCheck
+		return check, nil
+	}
+
+	_, err = bucket.Attrs(ctx)
+	if err != nil {
+		if !isGCSClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get bucket attributes to determine auth check : " + err.Error(),
+			}
+		}
+		check.AuthCheck = false
+	} else {
+		check.AuthCheck = true
+	}
+
+	return check, nil
+}
+
+func (c *GCSClient) LocFileDist(ctx *gin.Context, bucName, prefix string, distMp map[string]*dto.LakeFileDistStats) *errs.Errorf {
+
+	it := c.client.Bucket(bucName).Objects(ctx, &storage.Query{Prefix: prefix})
+
+	for {
+		obj, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to list gcs objects : " + err.Error(),
+			}
+		}
+
+		if ext := path.Ext(obj.Name); ext != "" {
+			if distMp[ext] == nil {
+				distMp[ext] = &dto.LakeFileDistStats{}
+			}
+
+			distMp[ext].TotalSize += obj.Size
+			distMp[ext].FileCount += 1
+		}
+	}
+
+	return nil
+}
+
+// isGCSClientErr reports whether err is a 4xx response from gcs, i.e. the request itself was denied/invalid.
+func isGCSClientErr(err error) bool {
+	if errors.Is(err, storage.ErrBucketNotExist) || errors.Is(err, storage.ErrObjectNotExist) {
+		return true
+	}
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && (gerr.Code >= 400 && gerr.Code < 500) {
+		return true
+	}
+	return false
+}
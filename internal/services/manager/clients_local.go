@@ -0,0 +1,170 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"lakelens/internal/adapters/engine"
+	localengine "lakelens/internal/adapters/local/engine"
+	"lakelens/internal/adapters/objstore"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocalClient is the CloudClient for directories on the local disk (or NFS mounts),
+// the sub directories of the root are the locations.
+type LocalClient struct {
+	root string
+}
+
+func (c *LocalClient) GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf) {
+
+	dirs, err := localengine.ListDirs(c.root)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to list directories from local root : " + err.Error(),
+		}
+	}
+
+	locs := make([]*dto.Locations, 0)
+	for _, dir := range dirs {
+		locs = append(locs, &dto.Locations{
+			Name:         &dir.Name,
+			CreationDate: dir.CreationDate,
+		})
+	}
+
+	return locs, nil
+}
+
+func (c *LocalClient) AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf) {
+
+	resp := new(dto.AddLocsResp)
+
+	for _, locName := range locNames {
+
+		dirName, prefix, _ := utils.ParseLocName(locName)
+		_, errf := localengine.GetDir(c.root, dirName)
+		if errf != nil {
+			if errf.ReturnRaw {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+			return nil, errf
+		}
+
+		if prefix != "" {
+			info, err := os.Stat(filepath.Join(c.root, dirName, filepath.FromSlash(prefix)))
+			if err != nil || !info.IsDir() {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+		}
+
+		resp.Added = append(resp.Added, locName)
+	}
+
+	return resp, nil
+}
+
+func (c *LocalClient) ProcessLoc(ctx *gin.Context, bucName, prefix string, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf) {
+
+	dir, errf := localengine.GetDir(c.root, bucName)
+	if errf != nil {
+		return nil, errf
+	}
+	dir.Prefix = prefix
+
+	newBucket, errf := engine.ScrapeLoc(ctx, objstore.NewLocalStore(c.root, bucName), dir, limiter, opts)
+	if errf != nil {
+		return newBucket, errf
+	}
+
+	return newBucket, nil
+}
+
+func (c *LocalClient) CheckLoc(ctx *gin.Context, bucName, prefix string) (*dto.LocCheckResp, *errs.Errorf) {
+
+	check := new(dto.LocCheckResp)
+	check.BucketName = bucName
+	check.Prefix = prefix
+
+	_, errf := localengine.GetDir(c.root, bucName)
+	if errf != nil {
+		if !errf.ReturnRaw {
+			return nil, errf
+		}
+		return check, nil
+	}
+	check.AuthCheck = true
+
+	dirPath := filepath.Join(c.root, bucName, filepath.FromSlash(prefix))
+
+	_, err := os.ReadDir(dirPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrPermission) && !errors.Is(err, fs.ErrNotExist) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrStorageFailed,
+				Message: "Failed to read directory to determine read check : " + err.Error(),
+			}
+		}
+		check.ReadCheck = false
+	} else {
+		check.ReadCheck = true
+	}
+
+	writeKey := filepath.Join(dirPath, fmt.Sprintf("%s.%d", "temp_obj_lakelens", time.Now().Unix()))
+	file, err := os.Create(writeKey)
+	if err != nil {
+		if !errors.Is(err, fs.ErrPermission) && !errors.Is(err, fs.ErrNotExist) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrStorageFailed,
+				Message: "Failed to create temp file to determine write check : " + err.Error(),
+			}
+		}
+		check.WriteCheck = false
+	} else {
+		file.Close()
+		os.Remove(writeKey)
+		check.WriteCheck = true
+	}
+
+	return check, nil
+}
+
+func (c *LocalClient) LocFileDist(ctx *gin.Context, bucName, prefix string, distMp map[string]*dto.LakeFileDistStats) *errs.Errorf {
+
+	_, errf := localengine.GetDir(c.root, bucName)
+	if errf != nil {
+		return errf
+	}
+
+	objs, err := objstore.ListAll(ctx, objstore.NewLocalStore(c.root, bucName), prefix, "")
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to list local files : " + err.Error(),
+		}
+	}
+
+	for _, obj := range objs.Objects {
+		if ext := path.Ext(obj.Key); ext != "" {
+			if distMp[ext] == nil {
+				distMp[ext] = &dto.LakeFileDistStats{}
+			}
+
+			distMp[ext].TotalSize += obj.Size
+			distMp[ext].FileCount += 1
+		}
+	}
+
+	return nil
+}
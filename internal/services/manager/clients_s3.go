@@ -0,0 +1,242 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"lakelens/internal/adapters/engine"
+	"lakelens/internal/adapters/objstore"
+	s3engine "lakelens/internal/adapters/s3/engine"
+	"lakelens/internal/consts/errs"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/transport/http"
+	"github.com/gin-gonic/gin"
+)
+
+// S3Client is the CloudClient for AWS S3 and every S3-compatible store (MinIO, Ceph RGW, etc).
+type S3Client struct {
+	client *s3.Client
+	ptype  string // the provider type, consts.AWSS3 or consts.MinIO
+}
+
+func (c *S3Client) GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf) {
+
+	bucs, err := s3engine.ListBuckets(ctx, c.client)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrDependencyFailed,
+			Message: "Failed to list buckets from s3 : " + err.Error(),
+		}
+	}
+
+	locs := make([]*dto.Locations, 0)
+	for _, buc := range bucs {
+		locs = append(locs, &dto.Locations{
+			Name:         buc.Name,
+			CreationDate: buc.CreationDate,
+			Region:       buc.BucketRegion,
+		})
+	}
+
+	return locs, nil
+}
+
+func (c *S3Client) AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf) {
+
+	resp := new(dto.AddLocsResp)
+
+	// TODO: can and mp should do this in parallel
+
+	for _, locName := range locNames {
+
+		bucName, prefix, _ := utils.ParseLocName(locName)
+		if prefix != "" {
+			// a prefix is validated by listing inside it, the bucket root might not be accessible.
+			oneObj := int32(1)
+			objs, err := c.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket:  &bucName,
+				Prefix:  &prefix,
+				MaxKeys: &oneObj,
+			})
+			if err != nil {
+				if isClientErr(err) {
+					resp.Failed = append(resp.Failed, locName)
+					continue
+				}
+				return nil, &errs.Errorf{
+					Type:    errs.ErrDependencyFailed,
+					Message: "Failed to list prefix to add location : " + err.Error(),
+				}
+			}
+			if len(objs.Contents) == 0 {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+
+			resp.Added = append(resp.Added, locName)
+			continue
+		}
+
+		_, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{
+			Bucket: &bucName,
+		})
+		if err != nil {
+			if isClientErr(err) {
+				resp.Failed = append(resp.Failed, locName)
+				continue
+			}
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get head bucket to add location : " + err.Error(),
+			}
+		}
+
+		resp.Added = append(resp.Added, locName)
+	}
+
+	return resp, nil
+}
+
+func (c *S3Client) ProcessLoc(ctx *gin.Context, bucName, prefix string, limiter *objstore.Limiter, opts dto.ScanOptions) (*dto.NewBucket, *errs.Errorf) {
+
+	data := &dto.BucketData{
+		Name:   bucName,
+		Prefix: prefix,
+	}
+
+	// head bucket needs access to the bucket root, which prefix scoped credentials usually lack.
+	if prefix == "" {
+		bucket, errf := s3engine.GetBucket(ctx, c.client, bucName)
+		if errf != nil {
+			return nil, errf
+		}
+		data.Region = bucket.BucketRegion
+		data.CreationDate = bucket.CreationDate
+	}
+
+	newBucket, errf := engine.ScrapeLoc(ctx, objstore.NewS3Store(c.client, bucName, c.ptype), data, limiter, opts)
+	if errf != nil {
+		return newBucket, errf
+	}
+
+	return newBucket, nil
+}
+
+func (c *S3Client) CheckLoc(ctx *gin.Context, bucName, prefix string) (*dto.LocCheckResp, *errs.Errorf) {
+
+	check := new(dto.LocCheckResp)
+	check.BucketName = bucName
+	check.Prefix = prefix
+
+	oneObj := int32(1)
+	_, err := c.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:  &bucName,
+		Prefix:  &prefix,
+		MaxKeys: &oneObj,
+	})
+	if err != nil {
+		if !isClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get one object to determine read check : " + err.Error(),
+			}
+		}
+		check.ReadCheck = false
+	} else {
+		check.ReadCheck = true
+	}
+
+	writeKey := prefix + fmt.Sprintf("%s.%d", "temp_obj_lakelens", time.Now().Unix())
+	_, err = c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &bucName,
+		Key:    &writeKey,
+	})
+	if err != nil {
+		if !isClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to put temp object to determine write check : " + err.Error(),
+			}
+		}
+		check.WriteCheck = false
+	} else {
+		check.WriteCheck = true
+	}
+
+	// the bucket root is never touched for a prefix, being able to list it is the auth check.
+	if prefix != "" {
+		check.AuthCheck = check.ReadCheck
+		return check, nil
+	}
+
+	_, err = c.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: &bucName,
+	})
+	if err != nil {
+		if !isClientErr(err) {
+			return nil, &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to get head bucket to determine auth check : " + err.Error(),
+			}
+		}
+		check.AuthCheck = false
+	} else {
+		check.AuthCheck = true
+	}
+
+	return check, nil
+}
+
+func (c *S3Client) LocFileDist(ctx *gin.Context, bucName, prefix string, distMp map[string]*dto.LakeFileDistStats) *errs.Errorf {
+
+	var continuationToken *string
+
+	for {
+		objs, err := c.client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:            &bucName,
+			Prefix:            &prefix,
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrDependencyFailed,
+				Message: "Failed to list s3 objects : " + err.Error(),
+			}
+		}
+
+		for _, b := range objs.Contents {
+			if ext := path.Ext(*b.Key); ext != "" {
+				if distMp[ext] == nil {
+					distMp[ext] = &dto.LakeFileDistStats{}
+				}
+
+				distMp[ext].TotalSize += *b.Size
+				distMp[ext].FileCount += 1
+			}
+		}
+
+		if objs.IsTruncated == nil || !*objs.IsTruncated {
+			break
+		}
+		continuationToken = objs.NextContinuationToken
+	}
+
+	return nil
+}
+
+// isClientErr reports whether err is a 4xx response from the provider, i.e. the request itself was denied/invalid.
+func isClientErr(err error) bool {
+	var serr *smithy.OperationError
+	if errors.As(err, &serr) {
+		var httperr *http.ResponseError
+		if errors.As(serr.Err, &httperr) && (httperr.HTTPStatusCode() >= 400 && httperr.HTTPStatusCode() < 500) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,7 +3,11 @@ package manager
 import (
 	"errors"
 	"fmt"
-	s3engine "lakelens/internal/adapters/s3/engine"
+	azengine "lakelens/internal/adapters/azure/engine"
+	gcsengine "lakelens/internal/adapters/gcs/engine"
+	localengine "lakelens/internal/adapters/local/engine"
+	"lakelens/internal/adapters/objstore"
+	configs "lakelens/internal/config"
 	"lakelens/internal/consts"
 	"lakelens/internal/consts/errs"
 	"lakelens/internal/dto"
@@ -11,20 +15,19 @@ import (
 	sqlc "lakelens/internal/sqlc/generate"
 	"lakelens/internal/stash"
 	utils "lakelens/internal/utils/common"
-	"path"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
-	"github.com/aws/smithy-go/transport/http"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/api/googleapi"
 )
 
 type ManagerService struct {
@@ -92,10 +95,19 @@ func (s *ManagerService) fetchCache(ctx *gin.Context, userID int64, locid string
 
 	cache := new(stash.CacheMetadata)
 	var exists bool
+	cacheKey := dto.CacheKey(locData.LakeID, locData.LocID)
 
 	switch lakeData.Ptype {
 	case consts.AWSS3:
-		cache, exists = s.Stash.GetBucketS3(locData.BucketName)
+		cache, exists = s.Stash.GetBucketS3(cacheKey)
+	case consts.MinIO:
+		cache, exists = s.Stash.GetBucketMinIO(cacheKey)
+	case consts.Azure:
+		cache, exists = s.Stash.GetBucketAzure(cacheKey)
+	case consts.GCS:
+		cache, exists = s.Stash.GetBucketGCS(cacheKey)
+	case consts.Local:
+		cache, exists = s.Stash.GetBucketLocal(cacheKey)
 
 	default:
 		// ?
@@ -115,7 +127,10 @@ func (s *ManagerService) fetchCache(ctx *gin.Context, userID int64, locid string
 // >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 // Registering a new lake
 
-func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name string, data *dto.NewLakeS3) (*dto.NewLakeResp, *errs.Errorf) {
+// processNewS3 registers a new AWS S3 or S3-compatible (ptype) lake.
+//
+// opts is only required for S3-compatible stores, pass nil for AWS S3.
+func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name, ptype string, data *dto.NewLakeS3, opts *dto.S3ClientOpts) (*dto.NewLakeResp, *errs.Errorf) {
 
 	// TODO:
 	// this gets weird here.
@@ -126,15 +141,22 @@ func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name strin
 	//    else tell the user that this is too heavy of a task and that he will need to lessen the number of locations.
 
 	// TODO: this should be gets3client only, the stash internally decides on new/cached ,etc.
-	client, err := s.Stash.NewS3Client(ctx, data.AccessID, data.AccessKey, data.LakeRegion, "")
+	client, err := s.Stash.NewS3Client(ctx, data.AccessID, data.AccessKey, data.LakeRegion, "", opts)
 	if err != nil {
 		return nil, &errs.Errorf{
 			Type:      errs.ErrInvalidCredentials,
-			Message:   "Invalid S3 lake credentials.",
+			Message:   "Invalid S3 lake credentials or endpoint : " + err.Error(),
 			ReturnRaw: true,
 		}
 	}
 
+	if opts == nil {
+		opts = new(dto.S3ClientOpts)
+	}
+	if ptype != consts.AWSS3 && data.LakeRegion == "" {
+		data.LakeRegion = consts.DefaultS3CompatRegion
+	}
+
 	buckets := make([]dto.Locations, 0)
 	var continueToken *string
 
@@ -147,7 +169,7 @@ func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name strin
 			if errors.As(err, &serr) {
 				return nil, &errs.Errorf{
 					Type:      errs.ErrInvalidCredentials,
-					Message:   "Invalid ID, Key or Region were provided. Please check your inputs.",
+					Message:   "Invalid ID, Key, Region or Endpoint were provided. Please check your inputs.",
 					ReturnRaw: true,
 				}
 			}
@@ -180,36 +202,254 @@ func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name strin
 		}
 	}
 
-	tx, err := s.DB.Begin(ctx)
+	lakeID, errf := s.insertNewLake(ctx, sqlc.InsertNewLakeParams{
+		UserID: userID,
+		Name:   name,
+		Region: data.LakeRegion,
+		Ptype:  ptype,
+	}, sqlc.InsertNewCredentailsParams{
+		KeyID:       data.AccessID,
+		Key:         cipherKey,
+		Region:      data.LakeRegion,
+		Endpoint:    opts.Endpoint,
+		PathStyle:   opts.UsePathStyle,
+		InsecureTls: opts.InsecureSkipVerify,
+	})
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &dto.NewLakeResp{
+		LakeID:    lakeID,
+		Locations: buckets,
+	}, nil
+}
+
+// processNewAzure registers a new Azure Blob Storage / ADLS Gen2 lake.
+func (s *ManagerService) processNewAzure(ctx *gin.Context, userID int64, name string, data *dto.NewLakeAzure) (*dto.NewLakeResp, *errs.Errorf) {
+
+	credType, key := consts.AzureSharedKey, data.AccountKey
+	if data.AccountKey == "" {
+		credType, key = consts.AzureSASToken, data.SASToken
+	}
+
+	client, err := s.Stash.NewAzureClient(data.AccountName, key, credType, data.Endpoint)
 	if err != nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidCredentials,
+			Message:   "Invalid Azure lake credentials or endpoint : " + err.Error(),
+			ReturnRaw: true,
+		}
+	}
+
+	containers, err := azengine.ListContainers(ctx, client)
+	if err != nil {
+		var respErr *azcore.ResponseError
+		if errors.As(err, &respErr) {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrInvalidCredentials,
+				Message:   "Invalid Account Name, Key/SAS Token or Endpoint were provided. Please check your inputs.",
+				ReturnRaw: true,
+			}
+		}
 		return nil, &errs.Errorf{
 			Type:    errs.ErrInternalServer,
-			Message: "Failed to generate db transaction : " + err.Error(),
+			Message: "Failed to list containers for client : " + err.Error(),
 		}
 	}
-	defer tx.Rollback(ctx)
 
-	qtx := s.Queries.WithTx(tx)
+	locations := make([]dto.Locations, 0)
+	for _, cont := range containers {
+		locations = append(locations, dto.Locations{
+			Name:         &cont.Name,
+			CreationDate: cont.CreationDate,
+			Region:       cont.Region,
+		})
+	}
 
-	lakeID, err := qtx.InsertNewLake(ctx, sqlc.InsertNewLakeParams{
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	cipherKey, err := utils.EncryptStringAESGSM(key)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to encrypt key : " + err.Error(),
+		}
+	}
+
+	lakeID, errf := s.insertNewLake(ctx, sqlc.InsertNewLakeParams{
 		UserID: userID,
 		Name:   name,
 		Region: data.LakeRegion,
-		Ptype:  consts.AWSS3,
+		Ptype:  consts.Azure,
+	}, sqlc.InsertNewCredentailsParams{
+		KeyID:    data.AccountName,
+		Key:      cipherKey,
+		Region:   data.LakeRegion,
+		Endpoint: data.Endpoint,
+		CredType: credType,
 	})
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &dto.NewLakeResp{
+		LakeID:    lakeID,
+		Locations: locations,
+	}, nil
+}
+
+// processNewGCS registers a new Google Cloud Storage lake from a service-account JSON key.
+func (s *ManagerService) processNewGCS(ctx *gin.Context, userID int64, name string, data *dto.NewLakeGCP) (*dto.NewLakeResp, *errs.Errorf) {
+
+	client, account, err := s.Stash.NewGCSClient(data.ServiceAccountJSON, data.Endpoint)
 	if err != nil {
 		return nil, &errs.Errorf{
-			Type:    errs.ErrDBQuery,
-			Message: "Failed to insert new lake in lakes : " + err.Error(),
+			Type:      errs.ErrInvalidCredentials,
+			Message:   "Invalid GCS lake credentials or endpoint : " + err.Error(),
+			ReturnRaw: true,
 		}
 	}
+	defer client.Close()
 
-	err = qtx.InsertNewCredentails(ctx, sqlc.InsertNewCredentailsParams{
-		LakeID: lakeID,
-		KeyID:  data.AccessID,
-		Key:    cipherKey,
+	buckets, err := gcsengine.ListBuckets(ctx, client, account.ProjectID)
+	if err != nil {
+		var gerr *googleapi.Error
+		if errors.As(err, &gerr) {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrInvalidCredentials,
+				Message:   "Invalid Service Account, Project or Endpoint were provided. Please check your inputs.",
+				ReturnRaw: true,
+			}
+		}
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to list buckets for client : " + err.Error(),
+		}
+	}
+
+	locations := make([]dto.Locations, 0)
+	for _, buc := range buckets {
+		locations = append(locations, dto.Locations{
+			Name:         &buc.Name,
+			CreationDate: buc.CreationDate,
+			Region:       buc.Region,
+		})
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	cipherKey, err := utils.EncryptStringAESGSM(data.ServiceAccountJSON)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to encrypt key : " + err.Error(),
+		}
+	}
+
+	lakeID, errf := s.insertNewLake(ctx, sqlc.InsertNewLakeParams{
+		UserID: userID,
+		Name:   name,
 		Region: data.LakeRegion,
+		Ptype:  consts.GCS,
+	}, sqlc.InsertNewCredentailsParams{
+		KeyID:    account.ClientEmail,
+		Key:      cipherKey,
+		Region:   data.LakeRegion,
+		Endpoint: data.Endpoint,
+	})
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &dto.NewLakeResp{
+		LakeID:    lakeID,
+		Locations: locations,
+	}, nil
+}
+
+// processNewLocal registers a new local (file://) lake, the root directory is stored as the endpoint.
+func (s *ManagerService) processNewLocal(ctx *gin.Context, userID int64, name string, data *dto.NewLakeLocal) (*dto.NewLakeResp, *errs.Errorf) {
+
+	root, err := s.Stash.NewLocalClient(data.RootURI)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidInput,
+			Message:   "Invalid local lake root : " + err.Error(),
+			ReturnRaw: true,
+		}
+	}
+
+	dirs, err := localengine.ListDirs(root)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to list directories for local root : " + err.Error(),
+		}
+	}
+
+	locations := make([]dto.Locations, 0)
+	for _, dir := range dirs {
+		locations = append(locations, dto.Locations{
+			Name:         &dir.Name,
+			CreationDate: dir.CreationDate,
+		})
+	}
+
+	// >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
+
+	// there is no secret for local lakes, but the key column is encrypted for every provider.
+	cipherKey, err := utils.EncryptStringAESGSM(root)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to encrypt key : " + err.Error(),
+		}
+	}
+
+	lakeID, errf := s.insertNewLake(ctx, sqlc.InsertNewLakeParams{
+		UserID: userID,
+		Name:   name,
+		Ptype:  consts.Local,
+	}, sqlc.InsertNewCredentailsParams{
+		KeyID:    fmt.Sprintf("%d:%s", userID, root),
+		Key:      cipherKey,
+		Endpoint: "file://" + root,
 	})
+	if errf != nil {
+		return nil, errf
+	}
+
+	return &dto.NewLakeResp{
+		LakeID:    lakeID,
+		Locations: locations,
+	}, nil
+}
+
+// insertNewLake inserts the lake and its (already encrypted) credentials in a single transaction.
+func (s *ManagerService) insertNewLake(ctx *gin.Context, lake sqlc.InsertNewLakeParams, creds sqlc.InsertNewCredentailsParams) (int64, *errs.Errorf) {
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to generate db transaction : " + err.Error(),
+		}
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.Queries.WithTx(tx)
+
+	lakeID, err := qtx.InsertNewLake(ctx, lake)
+	if err != nil {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to insert new lake in lakes : " + err.Error(),
+		}
+	}
+
+	creds.LakeID = lakeID
+	err = qtx.InsertNewCredentails(ctx, creds)
 	if err != nil {
 		errf := errs.Errorf{
 			Type:    errs.ErrDBQuery,
@@ -220,25 +460,22 @@ func (s *ManagerService) processNewS3(ctx *gin.Context, userID int64, name strin
 		if errors.As(err, &pgerr) {
 			if pgerr.Code == errs.PGErrUniqueViolation {
 				errf.Type = errs.ErrConflict
-				errf.Message = "Lake with given Id already exists. Please edit it."
+				errf.Message = "Lake with given Id and endpoint already exists. Please edit it."
 				errf.ReturnRaw = true
 			}
 		}
 
-		return nil, &errf
+		return 0, &errf
 	}
 	err = tx.Commit(ctx)
 	if err != nil {
-		return nil, &errs.Errorf{
+		return 0, &errs.Errorf{
 			Type:    errs.ErrDBConflict,
 			Message: "Failed to commit register new lake db transaction : " + err.Error(),
 		}
 	}
 
-	return &dto.NewLakeResp{
-		LakeID:    lakeID,
-		Locations: buckets,
-	}, nil
+	return lakeID, nil
 }
 
 // RegisterNewLake registers a new lake, retrieves available buckets.
@@ -254,13 +491,79 @@ func (s *ManagerService) RegisterNewLake(ctx *gin.Context, userID int64, data *d
 	if data.S3 != nil {
 		// process s3
 		fmt.Println("Adding new lake")
-		lakeResp, errf = s.processNewS3(ctx, userID, data.Name, data.S3)
+		lakeResp, errf = s.processNewS3(ctx, userID, data.Name, consts.AWSS3, data.S3, nil)
+		if errf != nil {
+			return nil, errf
+		}
+
+	} else if data.MinIO != nil {
+		// process any S3-compatible store
+		if data.MinIO.Endpoint == "" {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrMissingField,
+				Message:   "Endpoint is required for S3-compatible lakes.",
+				ReturnRaw: true,
+			}
+		}
+
+		lakeResp, errf = s.processNewS3(ctx, userID, data.Name, consts.MinIO, &dto.NewLakeS3{
+			AccessID:   data.MinIO.AccessID,
+			AccessKey:  data.MinIO.AccessKey,
+			LakeRegion: data.MinIO.LakeRegion,
+		}, &dto.S3ClientOpts{
+			Endpoint:           data.MinIO.Endpoint,
+			UsePathStyle:       data.MinIO.UsePathStyle,
+			InsecureSkipVerify: data.MinIO.InsecureSkipVerify,
+		})
 		if errf != nil {
 			return nil, errf
 		}
 
 	} else if data.Azure != nil {
 		// process azure
+		if data.Azure.AccountName == "" || (data.Azure.AccountKey == "") == (data.Azure.SASToken == "") {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrMissingField,
+				Message:   "Account name and exactly one of account key or SAS token are required for Azure lakes.",
+				ReturnRaw: true,
+			}
+		}
+
+		lakeResp, errf = s.processNewAzure(ctx, userID, data.Name, data.Azure)
+		if errf != nil {
+			return nil, errf
+		}
+
+	} else if data.GCP != nil {
+		// process gcs
+		if data.GCP.ServiceAccountJSON == "" {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrMissingField,
+				Message:   "Service account JSON key is required for GCS lakes.",
+				ReturnRaw: true,
+			}
+		}
+
+		lakeResp, errf = s.processNewGCS(ctx, userID, data.Name, data.GCP)
+		if errf != nil {
+			return nil, errf
+		}
+
+	} else if data.Local != nil {
+		// process local directory
+		if data.Local.RootURI == "" {
+			return nil, &errs.Errorf{
+				Type:      errs.ErrMissingField,
+				Message:   "Root directory is required for local lakes.",
+				ReturnRaw: true,
+			}
+		}
+
+		lakeResp, errf = s.processNewLocal(ctx, userID, data.Name, data.Local)
+		if errf != nil {
+			return nil, errf
+		}
+
 	} else {
 		return nil, &errs.Errorf{
 			Type:      errs.ErrBadForm,
@@ -405,6 +708,7 @@ func (s *ManagerService) AccProjects(ctx *gin.Context, userID int64) (*dto.AccPr
 			LocID:      loc.LocID,
 			LakeID:     loc.LakeID,
 			BucketName: loc.BucketName,
+			Prefix:     loc.Prefix,
 			CreatedAt:  loc.CreatedAt.Time,
 		}
 		combos[a.LakeID].Locs = append(combos[a.LakeID].Locs, a)
@@ -486,87 +790,39 @@ func (s *ManagerService) AccSettingsUpdate(ctx *gin.Context, data *dto.AccSettin
 // >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 // Analyzing a lake
 
-type CloudClient interface {
-	GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf)
-	AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf)
-	ProcessLake(ctx *gin.Context) ([]*dto.NewBucket, []*errs.Errorf)
-	ProcessLoc(ctx *gin.Context, bucName string) (*dto.NewBucket, *errs.Errorf)
-}
-
-type S3Client struct {
-	client *s3.Client
+func (s *ManagerService) handleGetLocs(ctx *gin.Context, c CloudClient) ([]*dto.Locations, *errs.Errorf) {
+	return c.GetLocs(ctx)
 }
-
-func (c *S3Client) GetLocs(ctx *gin.Context) ([]*dto.Locations, *errs.Errorf) {
-
-	bucs, err := s3engine.ListBuckets(ctx, c.client)
-	if err != nil {
-		return nil, &errs.Errorf{
-			Type:    errs.ErrDependencyFailed,
-			Message: "Failed to list buckets from s3 : " + err.Error(),
-		}
-	}
-
-	locs := make([]*dto.Locations, 0)
-	for _, buc := range bucs {
-		locs = append(locs, &dto.Locations{
-			Name:         buc.Name,
-			CreationDate: buc.CreationDate,
-			Region:       buc.BucketRegion,
-		})
-	}
-
-	return locs, nil
+func (s *ManagerService) handleAddLocs(ctx *gin.Context, locNames []string, c CloudClient) (*dto.AddLocsResp, *errs.Errorf) {
+	return c.AddLocs(ctx, locNames)
 }
-func (c *S3Client) AddLocs(ctx *gin.Context, locNames []string) (*dto.AddLocsResp, *errs.Errorf) {
-
-	resp := new(dto.AddLocsResp)
-
-	// TODO: can and mp should do this in parallel
-
-	for _, locName := range locNames {
-
-		_, err := c.client.HeadBucket(ctx, &s3.HeadBucketInput{
-			Bucket: &locName,
-		})
-		if err != nil {
-			var serr *smithy.OperationError
-			if errors.As(err, &serr) {
-				var httperr *http.ResponseError
-				if errors.As(serr.Err, &httperr) && (httperr.HTTPStatusCode() >= 400 && httperr.HTTPStatusCode() < 500) {
-					resp.Failed = append(resp.Failed, locName)
-					continue
-				}
-			}
-			return nil, &errs.Errorf{
-				Type:    errs.ErrDependencyFailed,
-				Message: "Failed to get head bucket to add location : " + err.Error(),
-			}
-		}
 
-		resp.Added = append(resp.Added, locName)
-	}
+// handleLakeAnalysis scrapes the registered locations of a lake at once, with one limiter for all of them.
+//
+// A location failing with a raw error keeps it on its bucket, so the other locations are still returned.
+func (s *ManagerService) handleLakeAnalysis(ctx *gin.Context, lakeID int64, ptype string, locs []sqlc.GetLocsListForLakeRow, opts dto.ScanOptions, c CloudClient) ([]*dto.NewBucket, []*errs.Errorf) {
 
-	return resp, nil
-}
-func (c *S3Client) ProcessLake(ctx *gin.Context) ([]*dto.NewBucket, []*errs.Errorf) {
-
-	buckets, err := s3engine.ListBuckets(ctx, c.client)
-	if err != nil {
-		return nil, nil
-	}
+	limiter := objstore.NewLimiter(configs.Extras.ProviderConcurrency(ptype))
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	response := make([]*dto.NewBucket, 0)
+	response := make([]*dto.NewBucket, 0, len(locs))
 	errorfs := make([]*errs.Errorf, 0)
 
-	for _, bucket := range buckets {
+	for _, loc := range locs {
 		wg.Add(1)
 
-		go func(bucket types.Bucket) {
+		go func(loc sqlc.GetLocsListForLakeRow) {
 			defer wg.Done()
-			newBucket, errf := s3engine.ScrapeLoc(ctx, c.client, &bucket)
+			newBucket, errf := c.ProcessLoc(ctx, loc.BucketName, loc.Prefix, limiter, opts)
+			if newBucket == nil {
+				newBucket = &dto.NewBucket{
+					Data: dto.BucketData{
+						Name:   loc.BucketName,
+						Prefix: loc.Prefix,
+					},
+				}
+			}
 			if errf != nil {
 				if errf.ReturnRaw {
 					newBucket.Errors = append(newBucket.Errors, errf)
@@ -574,43 +830,23 @@ func (c *S3Client) ProcessLake(ctx *gin.Context) ([]*dto.NewBucket, []*errs.Erro
 					mu.Lock()
 					errorfs = append(errorfs, errf)
 					mu.Unlock()
+					return
 				}
 			}
+			newBucket.Data.LakeID = lakeID
+			newBucket.Data.LocationID = loc.LocID
+
 			mu.Lock()
 			response = append(response, newBucket)
 			mu.Unlock()
-		}(bucket)
+		}(loc)
 	}
 	wg.Wait()
 
 	return response, errorfs
 }
-func (c *S3Client) ProcessLoc(ctx *gin.Context, bucName string) (*dto.NewBucket, *errs.Errorf) {
-
-	bucket, errf := s3engine.GetBucket(ctx, c.client, bucName)
-	if errf != nil {
-		return nil, errf
-	}
-
-	newBucket, errf := s3engine.ScrapeLoc(ctx, c.client, bucket)
-	if errf != nil {
-		return nil, errf
-	}
-
-	return newBucket, nil
-}
-
-func (s *ManagerService) handleGetLocs(ctx *gin.Context, c CloudClient) ([]*dto.Locations, *errs.Errorf) {
-	return c.GetLocs(ctx)
-}
-func (s *ManagerService) handleAddLocs(ctx *gin.Context, locNames []string, c CloudClient) (*dto.AddLocsResp, *errs.Errorf) {
-	return c.AddLocs(ctx, locNames)
-}
-func (s *ManagerService) handleLakeAnalysis(ctx *gin.Context, c CloudClient) ([]*dto.NewBucket, []*errs.Errorf) {
-	return c.ProcessLake(ctx)
-}
-func (s *ManagerService) handleLocAnalysis(ctx *gin.Context, bucName string, c CloudClient) (*dto.NewBucket, *errs.Errorf) {
-	return c.ProcessLoc(ctx, bucName)
+func (s *ManagerService) handleLocAnalysis(ctx *gin.Context, bucName, prefix, ptype string, opts dto.ScanOptions, c CloudClient) (*dto.NewBucket, *errs.Errorf) {
+	return c.ProcessLoc(ctx, bucName, prefix, objstore.NewLimiter(configs.Extras.ProviderConcurrency(ptype)), opts)
 }
 
 func (s *ManagerService) GetLocations(ctx *gin.Context, userID int64, lakeid string) ([]*dto.Locations, *errs.Errorf) {
@@ -639,26 +875,9 @@ func (s *ManagerService) GetLocations(ctx *gin.Context, userID int64, lakeid str
 		}
 	}
 
-	var client CloudClient
-
-	switch lakeData.Ptype {
-	case consts.AWSS3:
-		s3Client, err := s.Stash.GetS3Client(ctx, lakeID)
-		if err != nil {
-			return nil, nil
-		}
-		client = &S3Client{
-			client: s3Client,
-		}
-	case consts.Azure:
-		// init
-	default:
-		// init
-		fmt.Println("no provider match")
-		return nil, &errs.Errorf{
-			Type:    errs.ErrInternalServer,
-			Message: "The lake ptype did not match to any.",
-		}
+	client, errf := s.getCloudClient(ctx, lakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, errf
 	}
 
 	buckets, errf := s.handleGetLocs(ctx, client)
@@ -679,7 +898,8 @@ func (s *ManagerService) GetLocations(ctx *gin.Context, userID int64, lakeid str
 	// TODO: this is dumb.
 	for _, buc := range buckets {
 		for _, reg := range regLocs {
-			if *buc.Name == reg.BucketName {
+			// only the whole bucket counts, prefixes inside it can be added alongside.
+			if *buc.Name == reg.BucketName && reg.Prefix == "" {
 				buc.Registered = true
 				break
 			}
@@ -730,51 +950,45 @@ func (s *ManagerService) AddLocations(ctx *gin.Context, userID int64, data *dto.
 	}
 
 	toAdd := make([]string, 0)
+	invalid := make([]string, 0)
 	// TODO: this is dumb.
 	for _, req := range data.LocNames {
+		bucket, prefix, ok := utils.ParseLocName(req)
+		if !ok {
+			invalid = append(invalid, req)
+			continue
+		}
+
 		found := false
 		for _, loc := range locsList {
-			if req == loc.BucketName {
+			if bucket == loc.BucketName && prefix == loc.Prefix {
 				found = true
 				break
 			}
 		}
 		if !found {
-			toAdd = append(toAdd, req)
+			toAdd = append(toAdd, dto.LocKey(bucket, prefix))
 		}
 	}
 
-	var client CloudClient
-
-	switch lakeData.Ptype {
-	case consts.AWSS3:
-		s3Client, err := s.Stash.GetS3Client(ctx, data.LakeID)
-		if err != nil {
-			return nil, &errs.Errorf{
-				Type:    errs.ErrInternalServer,
-				Message: "Failed to get S3 client to add locations.",
-			}
-		}
-		client = &S3Client{
-			client: s3Client,
-		}
-	default:
-		return nil, &errs.Errorf{
-			Type:    errs.ErrInternalServer,
-			Message: "No provider type matched for add locations.",
-		}
+	client, errf := s.getCloudClient(ctx, data.LakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, errf
 	}
 
 	resp, errf := s.handleAddLocs(ctx, toAdd, client)
 	if errf != nil {
 		return nil, errf
 	}
+	resp.Failed = append(resp.Failed, invalid...)
 
 	for _, added := range resp.Added {
+		bucket, prefix, _ := utils.ParseLocName(added)
 		err = s.Queries.InsertNewLocation(ctx, sqlc.InsertNewLocationParams{
 			LakeID:     data.LakeID,
-			BucketName: added,
+			BucketName: bucket,
 			UserID:     userID,
+			Prefix:     prefix,
 		})
 		if err != nil {
 			return nil, &errs.Errorf{
@@ -946,6 +1160,7 @@ func (s *ManagerService) GetLakeDetails(ctx *gin.Context, userID int64, lakeid s
 			LocID:      loc.LocID,
 			LakeID:     lakeID,
 			BucketName: loc.BucketName,
+			Prefix:     loc.Prefix,
 			CreatedAt:  loc.CreatedAt.Time,
 		})
 	}
@@ -1005,94 +1220,31 @@ func (s *ManagerService) GetAllBucsChecks(ctx *gin.Context, userID int64, lakeid
 		}
 	}
 
-	s3Client, err := s.Stash.GetS3Client(ctx, lakeID)
+	lakeData, err := s.Queries.GetLakeDataForUserID(ctx, sqlc.GetLakeDataForUserIDParams{
+		UserID: userID,
+		LakeID: lakeID,
+	})
 	if err != nil {
 		return nil, &errs.Errorf{
-			Type:    errs.ErrDependencyFailed,
-			Message: "Failed to get s3 client : " + err.Error(),
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get lake data : " + err.Error(),
 		}
 	}
 
+	client, errf := s.getCloudClient(ctx, lakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, errf
+	}
+
 	resp := make([]*dto.LocCheckResp, 0)
 
 	for _, loc := range locsList {
 
-		check := new(dto.LocCheckResp)
-		check.LocID = loc.LocID
-		check.BucketName = loc.BucketName
-
-		oneObj := int32(1)
-		_, err := s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-			Bucket:  &loc.BucketName,
-			MaxKeys: &oneObj,
-		})
-		if err != nil {
-			var serr *smithy.OperationError
-			if errors.As(err, &serr) {
-				var httperr *http.ResponseError
-				if errors.As(serr.Err, &httperr) && (httperr.HTTPStatusCode() >= 400 && httperr.HTTPStatusCode() < 500) {
-					check.ReadCheck = false
-				} else {
-					return nil, &errs.Errorf{
-						Type:    errs.ErrDependencyFailed,
-						Message: "Failed to get one object to determine read check : " + err.Error(),
-					}
-				}
-			} else {
-				return nil, &errs.Errorf{
-					Type:    errs.ErrDependencyFailed,
-					Message: "Failed to get one object to determine read check : " + err.Error(),
-				}
-			}
-		} else {
-			check.ReadCheck = true
-		}
-
-		writeKey := fmt.Sprintf("%s.%d", "temp_obj_lakelens", time.Now().Unix())
-		_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
-			Bucket: &loc.BucketName,
-			Key:    &writeKey,
-		})
-		if err != nil {
-			var serr *smithy.OperationError
-			if errors.As(err, &serr) {
-				var httperr *http.ResponseError
-				if errors.As(serr.Err, &httperr) && (httperr.HTTPStatusCode() >= 400 && httperr.HTTPStatusCode() < 500) {
-					check.WriteCheck = false
-				} else {
-					return nil, &errs.Errorf{
-						Type:    errs.ErrDependencyFailed,
-						Message: "Failed to put temp object to determine write check : " + err.Error(),
-					}
-				}
-			} else {
-				return nil, &errs.Errorf{
-					Type:    errs.ErrDependencyFailed,
-					Message: "Failed to put temp object to determine write check : " + err.Error(),
-				}
-			}
-		} else {
-			check.WriteCheck = true
-		}
-
-		_, err = s3Client.HeadBucket(ctx, &s3.HeadBucketInput{
-			Bucket: &loc.BucketName,
-		})
-		if err != nil {
-			var serr *smithy.OperationError
-			if errors.As(err, &serr) {
-				var httperr *http.ResponseError
-				if errors.As(serr.Err, &httperr) && (httperr.HTTPStatusCode() >= 400 && httperr.HTTPStatusCode() < 500) {
-					check.AuthCheck = false
-				}
-			}
-			return nil, &errs.Errorf{
-				Type:    errs.ErrDependencyFailed,
-				Message: "Failed to get head bucket to add location : " + err.Error(),
-			}
-		} else {
-			check.AuthCheck = true
+		check, errf := client.CheckLoc(ctx, loc.BucketName, loc.Prefix)
+		if errf != nil {
+			return nil, errf
 		}
+		check.LocID = loc.LocID
 
 		resp = append(resp, check)
 	}
@@ -1128,45 +1280,28 @@ func (s *ManagerService) GetLakeFileDist(ctx *gin.Context, userID int64, lakeid
 		}
 	}
 
-	s3Client, err := s.Stash.GetS3Client(ctx, lakeID)
+	lakeData, err := s.Queries.GetLakeDataForUserID(ctx, sqlc.GetLakeDataForUserIDParams{
+		UserID: userID,
+		LakeID: lakeID,
+	})
 	if err != nil {
 		return nil, &errs.Errorf{
-			Type:    errs.ErrDependencyFailed,
-			Message: "Failed to get s3 client : " + err.Error(),
+			Type:    errs.ErrDBQuery,
+			Message: "Failed to get lake data : " + err.Error(),
 		}
 	}
 
+	client, errf := s.getCloudClient(ctx, lakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, errf
+	}
+
 	distMp := make(map[string]*dto.LakeFileDistStats, 0)
-	var continuationToken *string
 
 	for _, loc := range locsList {
-		for {
-			objs, err := s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
-				Bucket:            &loc.BucketName,
-				ContinuationToken: continuationToken,
-			})
-			if err != nil {
-				return nil, &errs.Errorf{
-					Type:    errs.ErrDependencyFailed,
-					Message: "Failed to list s3 objects : " + err.Error(),
-				}
-			}
-
-			for _, b := range objs.Contents {
-				if ext := path.Ext(*b.Key); ext != "" {
-					if distMp[ext] == nil {
-						distMp[ext] = &dto.LakeFileDistStats{}
-					}
-
-					distMp[ext].TotalSize += *b.Size
-					distMp[ext].FileCount += 1
-				}
-			}
-
-			if !*objs.IsTruncated {
-				break
-			}
-			continuationToken = objs.ContinuationToken
+		errf = client.LocFileDist(ctx, loc.BucketName, loc.Prefix, distMp)
+		if errf != nil {
+			return nil, errf
 		}
 	}
 
@@ -1177,7 +1312,7 @@ func (s *ManagerService) GetLakeFileDist(ctx *gin.Context, userID int64, lakeid
 
 // >>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>>
 
-func (s *ManagerService) AnalyzeLake(ctx *gin.Context, userID int64, lakeid string) ([]*dto.BucketData, []*errs.Errorf) {
+func (s *ManagerService) AnalyzeLake(ctx *gin.Context, userID int64, lakeid string, opts dto.ScanOptions) ([]*dto.BucketData, []*errs.Errorf) {
 
 	lakeID, err := strconv.ParseInt(lakeid, 10, 64)
 	if err != nil {
@@ -1197,35 +1332,33 @@ func (s *ManagerService) AnalyzeLake(ctx *gin.Context, userID int64, lakeid stri
 		return nil, nil
 	}
 
-	var client CloudClient
-
-	switch lakeData.Ptype {
-	case consts.AWSS3:
-		s3Client, err := s.Stash.GetS3Client(ctx, lakeID)
-		if err != nil {
-			return nil, nil
-		}
-		client = &S3Client{
-			client: s3Client,
-		}
-	case consts.Azure:
-		// init
-	case consts.MinIO:
-		// init
-	default:
-		// init
-		fmt.Println("no provider match")
-		return nil, nil
+	client, errf := s.getCloudClient(ctx, lakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, []*errs.Errorf{errf}
 	}
 
 	//
 
-	buckets, errfs := s.handleLakeAnalysis(ctx, client)
+	regLocs, err := s.Queries.GetLocsListForLake(ctx, sqlc.GetLocsListForLakeParams{
+		UserID: userID,
+		LakeID: lakeID,
+	})
+	if err != nil && err.Error() != errs.PGErrNoRowsFound {
+		return nil, []*errs.Errorf{
+			{
+				Type:    errs.ErrDBQuery,
+				Message: "Failed to get the lake locations : " + err.Error(),
+			},
+		}
+	}
+
+	// only the registered locations are scanned, a location may be a prefix inside a bucket.
+	buckets, errfs := s.handleLakeAnalysis(ctx, lakeID, lakeData.Ptype, regLocs, opts, client)
 	if len(errfs) != 0 {
 		return nil, errfs
 	}
 
-	bucsData := make([]*dto.BucketData, 0)
+	bucsData := make([]*dto.BucketData, 0, len(buckets))
 	for _, bucket := range buckets {
 		s.Stash.SetBucket(bucket)
 		bucsData = append(bucsData, &bucket.Data)
@@ -1234,7 +1367,7 @@ func (s *ManagerService) AnalyzeLake(ctx *gin.Context, userID int64, lakeid stri
 	return bucsData, nil
 }
 
-func (s *ManagerService) AnalyzeLoc(ctx *gin.Context, userID int64, locid string) (*dto.BucketData, *errs.Errorf) {
+func (s *ManagerService) AnalyzeLoc(ctx *gin.Context, userID int64, locid string, opts dto.ScanOptions) (*dto.BucketData, *errs.Errorf) {
 
 	locID, err := strconv.ParseInt(locid, 10, 64)
 	if err != nil {
@@ -1256,34 +1389,20 @@ func (s *ManagerService) AnalyzeLoc(ctx *gin.Context, userID int64, locid string
 		return nil, nil
 	}
 
-	var client CloudClient
-
-	switch lakeData.Ptype {
-	case consts.AWSS3:
-		s3Client, err := s.Stash.GetS3Client(ctx, locData.LakeID)
-		if err != nil {
-			return nil, nil
-		}
-		client = &S3Client{
-			client: s3Client,
-		}
-	case consts.Azure:
-		// init
-	case consts.MinIO:
-		// init
-	default:
-		// init
-		fmt.Println("no provider match")
-		return nil, nil
+	client, errf := s.getCloudClient(ctx, locData.LakeID, lakeData.Ptype)
+	if errf != nil {
+		return nil, errf
 	}
 
 	//
 
-	bucket, errf := s.handleLocAnalysis(ctx, locData.BucketName, client)
+	bucket, errf := s.handleLocAnalysis(ctx, locData.BucketName, locData.Prefix, lakeData.Ptype, opts, client)
 	if errf != nil {
 		return nil, errf
 	}
 
+	bucket.Data.LakeID = locData.LakeID
+	bucket.Data.LocationID = locData.LocID
 	s.Stash.SetBucket(bucket)
 
 	return &bucket.Data, nil
@@ -1298,3 +1417,28 @@ func (s *ManagerService) FetchLocation(ctx *gin.Context, userID int64, locid str
 
 	return cache.Bucket, nil
 }
+
+func (s *ManagerService) GetLocTables(ctx *gin.Context, userID int64, locid string) (*dto.LocTablesResp, *errs.Errorf) {
+
+	cache, errf := s.fetchCache(ctx, userID, locid)
+	if errf != nil {
+		return nil, errf
+	}
+
+	resp := &dto.LocTablesResp{
+		LocID:     cache.Bucket.Data.LocationID,
+		TableType: cache.Bucket.Data.TableType,
+		Tables:    make([]*dto.TableResp, 0, len(cache.Bucket.Tables)),
+	}
+
+	for _, table := range cache.Bucket.Tables {
+		resp.Tables = append(resp.Tables, &dto.TableResp{
+			ID:          table.ID,
+			TableType:   table.TableType,
+			URI:         table.URI,
+			ErrorsCount: len(table.Errors),
+		})
+	}
+
+	return resp, nil
+}
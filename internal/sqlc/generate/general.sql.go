@@ -279,7 +279,8 @@ SELECT
     locations.loc_id,
     locations.created_at,
     locations.bucket_name,
-    locations.lake_id
+    locations.lake_id,
+    locations.prefix
 FROM locations
 WHERE locations.user_id = $1
 `
@@ -289,6 +290,7 @@ type GetLocsListRow struct {
 	CreatedAt  pgtype.Timestamptz
 	BucketName string
 	LakeID     int64
+	Prefix     string
 }
 
 func (q *Queries) GetLocsList(ctx context.Context, userID int64) ([]GetLocsListRow, error) {
@@ -305,6 +307,7 @@ func (q *Queries) GetLocsList(ctx context.Context, userID int64) ([]GetLocsListR
 			&i.CreatedAt,
 			&i.BucketName,
 			&i.LakeID,
+			&i.Prefix,
 		); err != nil {
 			return nil, err
 		}
@@ -320,7 +323,8 @@ const getLocsListForLake = `-- name: GetLocsListForLake :many
 SELECT
     locations.loc_id,
     locations.created_at,
-    locations.bucket_name
+    locations.bucket_name,
+    locations.prefix
 FROM locations
 WHERE locations.user_id = $1 
 AND locations.lake_id = $2
@@ -335,6 +339,7 @@ type GetLocsListForLakeRow struct {
 	LocID      int64
 	CreatedAt  pgtype.Timestamptz
 	BucketName string
+	Prefix     string
 }
 
 func (q *Queries) GetLocsListForLake(ctx context.Context, arg GetLocsListForLakeParams) ([]GetLocsListForLakeRow, error) {
@@ -346,7 +351,12 @@ func (q *Queries) GetLocsListForLake(ctx context.Context, arg GetLocsListForLake
 	var items []GetLocsListForLakeRow
 	for rows.Next() {
 		var i GetLocsListForLakeRow
-		if err := rows.Scan(&i.LocID, &i.CreatedAt, &i.BucketName); err != nil {
+		if err := rows.Scan(
+			&i.LocID,
+			&i.CreatedAt,
+			&i.BucketName,
+			&i.Prefix,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -24,21 +24,37 @@ const getCredentials = `-- name: GetCredentials :one
 SELECT 
     credentials.key_id,
     credentials.key,
-    credentials.region
+    credentials.region,
+    credentials.endpoint,
+    credentials.path_style,
+    credentials.insecure_tls,
+    credentials.cred_type
 FROM credentials 
 WHERE lake_id = $1
 `
 
 type GetCredentialsRow struct {
-	KeyID  string
-	Key    string
-	Region string
+	KeyID       string
+	Key         string
+	Region      string
+	Endpoint    string
+	PathStyle   bool
+	InsecureTls bool
+	CredType    string
 }
 
 func (q *Queries) GetCredentials(ctx context.Context, lakeID int64) (GetCredentialsRow, error) {
 	row := q.db.QueryRow(ctx, getCredentials, lakeID)
 	var i GetCredentialsRow
-	err := row.Scan(&i.KeyID, &i.Key, &i.Region)
+	err := row.Scan(
+		&i.KeyID,
+		&i.Key,
+		&i.Region,
+		&i.Endpoint,
+		&i.PathStyle,
+		&i.InsecureTls,
+		&i.CredType,
+	)
 	return i, err
 }
 
@@ -76,7 +92,8 @@ SELECT
     locations.loc_id,
     locations.lake_id,
     locations.bucket_name,
-    locations.user_id
+    locations.user_id,
+    locations.prefix
 FROM locations 
 WHERE loc_id = $1
 `
@@ -86,6 +103,7 @@ type GetLocationDataRow struct {
 	LakeID     int64
 	BucketName string
 	UserID     int64
+	Prefix     string
 }
 
 func (q *Queries) GetLocationData(ctx context.Context, locID int64) (GetLocationDataRow, error) {
@@ -96,20 +114,25 @@ func (q *Queries) GetLocationData(ctx context.Context, locID int64) (GetLocation
 		&i.LakeID,
 		&i.BucketName,
 		&i.UserID,
+		&i.Prefix,
 	)
 	return i, err
 }
 
 const insertNewCredentails = `-- name: InsertNewCredentails :exec
-INSERT INTO credentials (lake_id, key_id, key, region)
-VALUES ($1, $2, $3, $4)
+INSERT INTO credentials (lake_id, key_id, key, region, endpoint, path_style, insecure_tls, cred_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type InsertNewCredentailsParams struct {
-	LakeID int64
-	KeyID  string
-	Key    string
-	Region string
+	LakeID      int64
+	KeyID       string
+	Key         string
+	Region      string
+	Endpoint    string
+	PathStyle   bool
+	InsecureTls bool
+	CredType    string
 }
 
 func (q *Queries) InsertNewCredentails(ctx context.Context, arg InsertNewCredentailsParams) error {
@@ -118,6 +141,10 @@ func (q *Queries) InsertNewCredentails(ctx context.Context, arg InsertNewCredent
 		arg.KeyID,
 		arg.Key,
 		arg.Region,
+		arg.Endpoint,
+		arg.PathStyle,
+		arg.InsecureTls,
+		arg.CredType,
 	)
 	return err
 }
@@ -148,17 +175,23 @@ func (q *Queries) InsertNewLake(ctx context.Context, arg InsertNewLakeParams) (i
 }
 
 const insertNewLocation = `-- name: InsertNewLocation :exec
-INSERT INTO locations (lake_id, bucket_name, user_id)
-VALUES ($1, $2, $3)
+INSERT INTO locations (lake_id, bucket_name, user_id, prefix)
+VALUES ($1, $2, $3, $4)
 `
 
 type InsertNewLocationParams struct {
 	LakeID     int64
 	BucketName string
 	UserID     int64
+	Prefix     string
 }
 
 func (q *Queries) InsertNewLocation(ctx context.Context, arg InsertNewLocationParams) error {
-	_, err := q.db.Exec(ctx, insertNewLocation, arg.LakeID, arg.BucketName, arg.UserID)
+	_, err := q.db.Exec(ctx, insertNewLocation,
+		arg.LakeID,
+		arg.BucketName,
+		arg.UserID,
+		arg.Prefix,
+	)
 	return err
 }
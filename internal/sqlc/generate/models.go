@@ -9,12 +9,16 @@ import (
 )
 
 type Credential struct {
-	CredID    int64
-	LakeID    int64
-	CreatedAt pgtype.Timestamptz
-	KeyID     string
-	Key       string
-	Region    string
+	CredID      int64
+	LakeID      int64
+	CreatedAt   pgtype.Timestamptz
+	KeyID       string
+	Key         string
+	Region      string
+	Endpoint    string
+	PathStyle   bool
+	InsecureTls bool
+	CredType    string
 }
 
 type Epauth struct {
@@ -50,6 +54,7 @@ type Location struct {
 	CreatedAt  pgtype.Timestamptz
 	BucketName string
 	UserID     int64
+	Prefix     string
 }
 
 type Recent struct {
@@ -127,7 +127,8 @@ SELECT
     locations.loc_id,
     locations.created_at,
     locations.bucket_name,
-    locations.lake_id
+    locations.lake_id,
+    locations.prefix
 FROM locations
 WHERE locations.user_id = $1;
 
@@ -135,7 +136,8 @@ WHERE locations.user_id = $1;
 SELECT
     locations.loc_id,
     locations.created_at,
-    locations.bucket_name
+    locations.bucket_name,
+    locations.prefix
 FROM locations
 WHERE locations.user_id = $1 
 AND locations.lake_id = $2;
@@ -5,8 +5,8 @@ VALUES ($1, $2, $3, $4)
 RETURNING lake_id;
 
 -- name: InsertNewLocation :exec
-INSERT INTO locations (lake_id, bucket_name, user_id)
-VALUES ($1, $2, $3);
+INSERT INTO locations (lake_id, bucket_name, user_id, prefix)
+VALUES ($1, $2, $3, $4);
 
 
 -- name: GetLakeData :one
@@ -24,22 +24,27 @@ SELECT
     locations.loc_id,
     locations.lake_id,
     locations.bucket_name,
-    locations.user_id
+    locations.user_id,
+    locations.prefix
 FROM locations 
 WHERE loc_id = $1;
 
 
 
 -- name: InsertNewCredentails :exec
-INSERT INTO credentials (lake_id, key_id, key, region)
-VALUES ($1, $2, $3, $4);
+INSERT INTO credentials (lake_id, key_id, key, region, endpoint, path_style, insecure_tls, cred_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 
 -- name: GetCredentials :one
 SELECT 
     credentials.key_id,
     credentials.key,
-    credentials.region
+    credentials.region,
+    credentials.endpoint,
+    credentials.path_style,
+    credentials.insecure_tls,
+    credentials.cred_type
 FROM credentials 
 WHERE lake_id = $1; 
 
@@ -110,9 +110,12 @@ CREATE TABLE IF NOT EXISTS public.credentials
     key_id text COLLATE pg_catalog."default" NOT NULL,
     key text COLLATE pg_catalog."default" NOT NULL,
     region text COLLATE pg_catalog."default" NOT NULL,
+    endpoint text COLLATE pg_catalog."default" NOT NULL DEFAULT ''::text,
+    path_style boolean NOT NULL DEFAULT false,
+    insecure_tls boolean NOT NULL DEFAULT false,
+    cred_type text COLLATE pg_catalog."default" NOT NULL DEFAULT ''::text,
     CONSTRAINT credentials_pkey PRIMARY KEY (cred_id),
-    CONSTRAINT unique_key_id UNIQUE (key_id)
-        INCLUDE(key_id),
+    CONSTRAINT unique_key_id UNIQUE (key_id, endpoint),
     CONSTRAINT lakes_lake_id_fkey FOREIGN KEY (lake_id)
         REFERENCES public.lakes (lake_id) MATCH SIMPLE
         ON UPDATE CASCADE
@@ -128,6 +131,7 @@ CREATE TABLE IF NOT EXISTS public.locations
     created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
     bucket_name text COLLATE pg_catalog."default" NOT NULL,
     user_id bigint NOT NULL DEFAULT 1,
+    prefix text COLLATE pg_catalog."default" NOT NULL DEFAULT ''::text,
     CONSTRAINT locations_pkey PRIMARY KEY (loc_id),
     CONSTRAINT lakes_lake_id_fkey FOREIGN KEY (lake_id)
         REFERENCES public.lakes (lake_id) MATCH SIMPLE
@@ -1,12 +1,17 @@
 package stash
 
 import (
+	"crypto/tls"
 	"fmt"
+	"lakelens/internal/consts"
 	"lakelens/internal/dto"
 	utils "lakelens/internal/utils/common"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
@@ -27,7 +32,16 @@ func (s *StashService) SetS3Client(ctx *gin.Context, lakeID int64) error {
 		return fmt.Errorf("failed to decrypt key : %v", err)
 	}
 
-	s3client, err := s.NewS3Client(ctx, creds.KeyID, lakeKey, creds.Region, "")
+	var opts *dto.S3ClientOpts
+	if creds.Endpoint != "" {
+		opts = &dto.S3ClientOpts{
+			Endpoint:           creds.Endpoint,
+			UsePathStyle:       creds.PathStyle,
+			InsecureSkipVerify: creds.InsecureTls,
+		}
+	}
+
+	s3client, err := s.NewS3Client(ctx, creds.KeyID, lakeKey, creds.Region, "", opts)
 	if err != nil {
 		return fmt.Errorf("failed to create new s3 client : %v", err)
 	}
@@ -46,7 +60,9 @@ func (s *StashService) SetS3Client(ctx *gin.Context, lakeID int64) error {
 
 func (s *StashService) GetS3Client(ctx *gin.Context, lakeID int64) (*s3.Client, error) {
 
+	s.cliMU.Lock()
 	client, ok := s.clients.S3[fmt.Sprintf("%d", lakeID)]
+	s.cliMU.Unlock()
 	if !ok || client == nil {
 		err := s.SetS3Client(ctx, lakeID)
 		if err != nil {
@@ -58,20 +74,56 @@ func (s *StashService) GetS3Client(ctx *gin.Context, lakeID int64) (*s3.Client,
 	return client.S3Client, nil
 }
 
-func (s *StashService) NewS3Client(ctx *gin.Context, keyId, key, region, sessionStr string) (*s3.Client, error) {
+// NewS3Client builds a new s3 client.
+//
+// opts is only required for S3-compatible stores (MinIO, Ceph RGW, etc), pass nil for AWS S3.
+func (s *StashService) NewS3Client(ctx *gin.Context, keyId, key, region, sessionStr string, opts *dto.S3ClientOpts) (*s3.Client, error) {
 	// TODO: check and validate args
-	config, err := config.LoadDefaultConfig(ctx,
+
+	if opts != nil && region == "" {
+		region = consts.DefaultS3CompatRegion
+	}
+
+	cfgOpts := []func(*config.LoadOptions) error{
 		config.WithRegion(region),
 		config.WithCredentialsProvider(
 			credentials.NewStaticCredentialsProvider(keyId, key, sessionStr),
 		),
-	)
+	}
+
+	if opts != nil && opts.InsecureSkipVerify {
+		httpClient := awshttp.NewBuildableClient().WithTransportOptions(func(tr *http.Transport) {
+			if tr.TLSClientConfig == nil {
+				tr.TLSClientConfig = &tls.Config{}
+			}
+			tr.TLSClientConfig.InsecureSkipVerify = true
+		})
+		cfgOpts = append(cfgOpts, config.WithHTTPClient(httpClient))
+	}
+
+	config, err := config.LoadDefaultConfig(ctx, cfgOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load a default config : %v", err)
 	}
 
+	if opts != nil && opts.Endpoint != "" {
+		endpoint, err := url.Parse(opts.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint url, expected http(s)://host[:port] : %s", opts.Endpoint)
+		}
+	}
+
 	client := s3.NewFromConfig(config, func(o *s3.Options) {
 		o.ResponseChecksumValidation = aws.ResponseChecksumValidation(0)
+
+		if opts != nil {
+			if opts.Endpoint != "" {
+				o.BaseEndpoint = aws.String(opts.Endpoint)
+			}
+			o.UsePathStyle = opts.UsePathStyle
+			// most S3-compatible stores don't support the newer default checksum trailers.
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		}
 	})
 
 	return client, nil
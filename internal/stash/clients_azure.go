@@ -0,0 +1,97 @@
+package stash
+
+import (
+	"fmt"
+	"lakelens/internal/consts"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *StashService) SetAzureClient(ctx *gin.Context, lakeID int64) error {
+
+	creds, err := s.Queries.GetCredentials(ctx, lakeID)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials : %v", err)
+	}
+
+	lakeKey, err := utils.DecryptStringAESGSM(creds.Key)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt key : %v", err)
+	}
+
+	azClient, err := s.NewAzureClient(creds.KeyID, lakeKey, creds.CredType, creds.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create new azure client : %v", err)
+	}
+
+	data := dto.AzureClientSave{
+		LastUsed:    time.Now(),
+		AzureClient: azClient,
+	}
+
+	s.cliMU.Lock()
+	s.clients.Azure[fmt.Sprintf("%d", lakeID)] = &data
+	s.cliMU.Unlock()
+
+	return nil
+}
+
+func (s *StashService) GetAzureClient(ctx *gin.Context, lakeID int64) (*azblob.Client, error) {
+
+	s.cliMU.Lock()
+	client, ok := s.clients.Azure[fmt.Sprintf("%d", lakeID)]
+	s.cliMU.Unlock()
+	if !ok || client == nil {
+		err := s.SetAzureClient(ctx, lakeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Azure client from cache for key : %d", lakeID)
+		}
+		return s.GetAzureClient(ctx, lakeID)
+	}
+
+	return client.AzureClient, nil
+}
+
+// NewAzureClient builds a new azure blob client.
+//
+// credType is one of consts.AzureSharedKey or consts.AzureSASToken, key is the account key or the SAS token respectively.
+// endpoint is optional and defaults to the public blob endpoint of the account, set it for Azurite or sovereign clouds.
+func (s *StashService) NewAzureClient(accountName, key, credType, endpoint string) (*azblob.Client, error) {
+
+	if accountName == "" || key == "" {
+		return nil, fmt.Errorf("account name and key/sas token cannot be empty")
+	}
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf(consts.AzureBlobEndpointFmt, accountName)
+	}
+	serviceURL, err := url.Parse(endpoint)
+	if err != nil || (serviceURL.Scheme != "http" && serviceURL.Scheme != "https") || serviceURL.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint url, expected http(s)://host[:port][/account] : %s", endpoint)
+	}
+	if !strings.HasSuffix(serviceURL.Path, "/") {
+		serviceURL.Path += "/"
+	}
+
+	switch credType {
+	case consts.AzureSharedKey:
+		cred, err := azblob.NewSharedKeyCredential(accountName, key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shared key credential : %v", err)
+		}
+		return azblob.NewClientWithSharedKeyCredential(serviceURL.String(), cred, nil)
+
+	case consts.AzureSASToken:
+		serviceURL.RawQuery = strings.TrimPrefix(key, "?")
+		return azblob.NewClientWithNoCredential(serviceURL.String(), nil)
+
+	default:
+		return nil, fmt.Errorf("unknown azure credential type : %s", credType)
+	}
+}
@@ -0,0 +1,105 @@
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lakelens/internal/dto"
+	utils "lakelens/internal/utils/common"
+	"net/url"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/api/option"
+)
+
+func (s *StashService) SetGCSClient(ctx *gin.Context, lakeID int64) error {
+
+	creds, err := s.Queries.GetCredentials(ctx, lakeID)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials : %v", err)
+	}
+
+	lakeKey, err := utils.DecryptStringAESGSM(creds.Key)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt key : %v", err)
+	}
+
+	gcsClient, account, err := s.NewGCSClient(lakeKey, creds.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create new gcs client : %v", err)
+	}
+
+	data := dto.GCSClientSave{
+		LastUsed:  time.Now(),
+		GCSClient: gcsClient,
+		ProjectID: account.ProjectID,
+	}
+
+	s.cliMU.Lock()
+	s.clients.GCS[fmt.Sprintf("%d", lakeID)] = &data
+	s.cliMU.Unlock()
+
+	return nil
+}
+
+// GetGCSClient returns the gcs client along with the project id of the lake.
+func (s *StashService) GetGCSClient(ctx *gin.Context, lakeID int64) (*storage.Client, string, error) {
+
+	s.cliMU.Lock()
+	client, ok := s.clients.GCS[fmt.Sprintf("%d", lakeID)]
+	s.cliMU.Unlock()
+	if !ok || client == nil {
+		err := s.SetGCSClient(ctx, lakeID)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load GCS client from cache for key : %d", lakeID)
+		}
+		return s.GetGCSClient(ctx, lakeID)
+	}
+
+	return client.GCSClient, client.ProjectID, nil
+}
+
+// NewGCSClient builds a new gcs client from a service-account JSON key.
+//
+// endpoint is optional, set it for fake-gcs-server and the like.
+// Emulators do not check auth, so with a custom endpoint the private key can be left out of the JSON.
+func (s *StashService) NewGCSClient(credsJSON, endpoint string) (*storage.Client, *dto.GCSServiceAccount, error) {
+
+	account := new(dto.GCSServiceAccount)
+	err := json.Unmarshal([]byte(credsJSON), account)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid service account json : %v", err)
+	}
+	if account.Type != "service_account" || account.ProjectID == "" || account.ClientEmail == "" {
+		return nil, nil, fmt.Errorf("service account json needs type 'service_account', project_id and client_email")
+	}
+
+	opts := make([]option.ClientOption, 0)
+
+	if endpoint != "" {
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || (endpointURL.Scheme != "http" && endpointURL.Scheme != "https") || endpointURL.Host == "" {
+			return nil, nil, fmt.Errorf("invalid endpoint url, expected http(s)://host[:port]/storage/v1/ : %s", endpoint)
+		}
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+
+	switch {
+	case account.PrivateKey != "":
+		opts = append(opts, option.WithCredentialsJSON([]byte(credsJSON)))
+	case endpoint != "":
+		opts = append(opts, option.WithoutAuthentication())
+	default:
+		return nil, nil, fmt.Errorf("service account json is missing the private_key")
+	}
+
+	// the client outlives the request, so it must not be bound to the request context.
+	client, err := storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create storage client : %v", err)
+	}
+
+	return client, account, nil
+}
@@ -0,0 +1,107 @@
+package stash
+
+import (
+	"fmt"
+	configs "lakelens/internal/config"
+	"lakelens/internal/dto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *StashService) SetLocalClient(ctx *gin.Context, lakeID int64) error {
+
+	creds, err := s.Queries.GetCredentials(ctx, lakeID)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials : %v", err)
+	}
+
+	// the allowed roots may have changed since the lake was registered, so validate again.
+	root, err := s.NewLocalClient(creds.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to resolve local lake root : %v", err)
+	}
+
+	data := dto.LocalClientSave{
+		LastUsed: time.Now(),
+		Root:     root,
+	}
+
+	s.cliMU.Lock()
+	s.clients.Local[fmt.Sprintf("%d", lakeID)] = &data
+	s.cliMU.Unlock()
+
+	return nil
+}
+
+// GetLocalClient returns the absolute root directory of the local lake.
+func (s *StashService) GetLocalClient(ctx *gin.Context, lakeID int64) (string, error) {
+
+	s.cliMU.Lock()
+	client, ok := s.clients.Local[fmt.Sprintf("%d", lakeID)]
+	s.cliMU.Unlock()
+	if !ok || client == nil {
+		err := s.SetLocalClient(ctx, lakeID)
+		if err != nil {
+			return "", fmt.Errorf("failed to load local client from cache for key : %d", lakeID)
+		}
+		return s.GetLocalClient(ctx, lakeID)
+	}
+
+	return client.Root, nil
+}
+
+// NewLocalClient resolves and validates the root directory of a local lake.
+//
+// rootURI can be file:///abs/path or just /abs/path. Symlinks are resolved,
+// and the final directory has to be under one of configs.Paths.LocalLakeAllowedRoots.
+func (s *StashService) NewLocalClient(rootURI string) (string, error) {
+
+	rootPath := rootURI
+	if strings.HasPrefix(rootURI, "file:") {
+		u, err := url.Parse(rootURI)
+		if err != nil || (u.Host != "" && u.Host != "localhost") {
+			return "", fmt.Errorf("invalid file uri, expected file:///abs/path : %s", rootURI)
+		}
+		rootPath = u.Path
+	}
+
+	if !filepath.IsAbs(rootPath) {
+		return "", fmt.Errorf("lake root must be an absolute path : %s", rootURI)
+	}
+
+	root, err := filepath.EvalSymlinks(filepath.Clean(rootPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve lake root : %v", err)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat lake root : %v", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("lake root is not a directory : %s", root)
+	}
+
+	for _, allowed := range configs.Paths.LocalLakeAllowedRoots {
+		allowedAbs, err := filepath.Abs(allowed)
+		if err != nil {
+			continue
+		}
+		allowedAbs, err = filepath.EvalSymlinks(allowedAbs)
+		if err != nil {
+			continue
+		}
+
+		rel, err := filepath.Rel(allowedAbs, root)
+		if err == nil && filepath.IsLocal(rel) {
+			return root, nil
+		}
+	}
+
+	return "", fmt.Errorf("lake root is not under any of the allowed roots : %s", root)
+}
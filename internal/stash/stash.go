@@ -25,6 +25,10 @@ type CacheMetadata struct {
 
 type buckets struct {
 	s3 map[string]*CacheMetadata 
+	minio map[string]*CacheMetadata
+	azure map[string]*CacheMetadata
+	gcs map[string]*CacheMetadata
+	local map[string]*CacheMetadata
 	
 	// every provider has a separate pool for bucket caching
 }
@@ -32,6 +36,9 @@ type buckets struct {
 
 type clients struct {
 	S3 map[string]*dto.S3ClientSave
+	Azure map[string]*dto.AzureClientSave
+	GCS map[string]*dto.GCSClientSave
+	Local map[string]*dto.LocalClientSave
 
 	// each provider type has its own client store
 }
@@ -62,11 +69,18 @@ func NewStashService(queries *sqlc.Queries, redis *redis.Client, db *pgxpool.Poo
 
 		buckets: &buckets{
 			s3: make(map[string]*CacheMetadata),
+			minio: make(map[string]*CacheMetadata),
+			azure: make(map[string]*CacheMetadata),
+			gcs: make(map[string]*CacheMetadata),
+			local: make(map[string]*CacheMetadata),
 		},
 		bucMU: sync.Mutex{},
 
 		clients: &clients{
 			S3: make(map[string]*dto.S3ClientSave),
+			Azure: make(map[string]*dto.AzureClientSave),
+			GCS: make(map[string]*dto.GCSClientSave),
+			Local: make(map[string]*dto.LocalClientSave),
 		},
 		cliMU: sync.Mutex{},
 	}
@@ -74,18 +88,52 @@ func NewStashService(queries *sqlc.Queries, redis *redis.Client, db *pgxpool.Poo
 
 func (c *StashService) SetBucket(bucket *dto.NewBucket) {
 
+	// locations are cached by their lake and location ids, many locations can share a bucket.
+	key := bucket.Data.CacheKey()
+
 	c.bucMU.Lock()
 	switch bucket.Data.StorageType {
 	case consts.AWSS3:
 		// cache in s3
-		c.DelBucketS3(bucket.Data.Name)
-		c.buckets.s3[bucket.Data.Name] = &CacheMetadata{
+		c.DelBucketS3(key)
+		c.buckets.s3[key] = &CacheMetadata{
+			Bucket: bucket,
+			CreatedAt: time.Now().UnixMilli(),
+			UpdatedAt: bucket.Data.UpdatedAt,
+			KeyCount: bucket.Data.KeyCount,
+		}
+	case consts.MinIO:
+		c.DelBucketMinIO(key)
+		c.buckets.minio[key] = &CacheMetadata{
+			Bucket: bucket,
+			CreatedAt: time.Now().UnixMilli(),
+			UpdatedAt: bucket.Data.UpdatedAt,
+			KeyCount: bucket.Data.KeyCount,
+		}
+	case consts.Azure:
+		c.DelBucketAzure(key)
+		c.buckets.azure[key] = &CacheMetadata{
+			Bucket: bucket,
+			CreatedAt: time.Now().UnixMilli(),
+			UpdatedAt: bucket.Data.UpdatedAt,
+			KeyCount: bucket.Data.KeyCount,
+		}
+	case consts.GCS:
+		c.DelBucketGCS(key)
+		c.buckets.gcs[key] = &CacheMetadata{
+			Bucket: bucket,
+			CreatedAt: time.Now().UnixMilli(),
+			UpdatedAt: bucket.Data.UpdatedAt,
+			KeyCount: bucket.Data.KeyCount,
+		}
+	case consts.Local:
+		c.DelBucketLocal(key)
+		c.buckets.local[key] = &CacheMetadata{
 			Bucket: bucket,
 			CreatedAt: time.Now().UnixMilli(),
 			UpdatedAt: bucket.Data.UpdatedAt,
 			KeyCount: bucket.Data.KeyCount,
 		}
-	// case 
 	default:
 		// unknown, err out
 	}
@@ -94,16 +142,57 @@ func (c *StashService) SetBucket(bucket *dto.NewBucket) {
 
 
 
-func (c *StashService) GetBucketS3(bucketName string) (*CacheMetadata, bool) {
+func (c *StashService) GetBucketS3(cacheKey string) (*CacheMetadata, bool) {
+	c.bucMU.Lock()
+	bucData, ok := c.buckets.s3[cacheKey]
+	c.bucMU.Unlock()
+	return bucData, ok
+}
+
+func (c *StashService) DelBucketS3(cacheKey string) {
+	delete(c.buckets.s3, cacheKey)
+}
+
+func (c *StashService) GetBucketMinIO(cacheKey string) (*CacheMetadata, bool) {
+	c.bucMU.Lock()
+	bucData, ok := c.buckets.minio[cacheKey]
+	c.bucMU.Unlock()
+	return bucData, ok
+}
+
+func (c *StashService) DelBucketMinIO(cacheKey string) {
+	delete(c.buckets.minio, cacheKey)
+}
+
+func (c *StashService) GetBucketAzure(cacheKey string) (*CacheMetadata, bool) {
 	c.bucMU.Lock()
-	bucData, ok := c.buckets.s3[bucketName]
+	bucData, ok := c.buckets.azure[cacheKey]
 	c.bucMU.Unlock()
 	return bucData, ok
 }
 
-func (c *StashService) DelBucketS3(bucketName string) {
-	delete(c.buckets.s3, bucketName)
+func (c *StashService) DelBucketAzure(cacheKey string) {
+	delete(c.buckets.azure, cacheKey)
 }
 
+func (c *StashService) GetBucketGCS(cacheKey string) (*CacheMetadata, bool) {
+	c.bucMU.Lock()
+	bucData, ok := c.buckets.gcs[cacheKey]
+	c.bucMU.Unlock()
+	return bucData, ok
+}
 
+func (c *StashService) DelBucketGCS(cacheKey string) {
+	delete(c.buckets.gcs, cacheKey)
+}
 
+func (c *StashService) GetBucketLocal(cacheKey string) (*CacheMetadata, bool) {
+	c.bucMU.Lock()
+	bucData, ok := c.buckets.local[cacheKey]
+	c.bucMU.Unlock()
+	return bucData, ok
+}
+
+func (c *StashService) DelBucketLocal(cacheKey string) {
+	delete(c.buckets.local, cacheKey)
+}
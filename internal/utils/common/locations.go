@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"path"
+	"strings"
+)
+
+// ParseLocName splits a location name into its bucket and prefix.
+//
+// The name can be a bucket name, bucket/some/prefix/ or a full uri like s3://bucket/some/prefix/.
+// The returned prefix is empty for a whole bucket, else it always ends with a '/'.
+// Returns false if the name is not a valid location.
+func ParseLocName(name string) (string, string, bool) {
+
+	if _, after, found := strings.Cut(name, "://"); found {
+		name = after
+	}
+	name = strings.TrimPrefix(name, "/")
+
+	bucket, prefix, _ := strings.Cut(name, "/")
+	if bucket == "" || bucket == "." || bucket == ".." {
+		return "", "", false
+	}
+
+	if prefix == "" {
+		return bucket, "", true
+	}
+
+	for _, seg := range strings.Split(strings.TrimSuffix(prefix, "/"), "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", "", false
+		}
+	}
+
+	return bucket, path.Clean(prefix) + "/", true
+}
@@ -0,0 +1,174 @@
+package deltautils
+
+import (
+	"encoding/json"
+	"fmt"
+	"lakelens/internal/consts/errs"
+	formats "lakelens/internal/dto/formats/delta"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// The change types of the change data feed, in the _change_type column.
+const (
+	ChangeInsert          = "insert"
+	ChangeUpdatePreimage  = "update_preimage"
+	ChangeUpdatePostimage = "update_postimage"
+	ChangeDelete          = "delete"
+
+	ChangeTypeColumn = "_change_type"
+)
+
+// ReadCDCFile reads the given change data file, counting its rows per change type and keeping its
+// first rowsLimit rows.
+func ReadCDCFile(filePath string, rowsLimit int) (cdcFile *formats.DeltaCDCFile, errf *errs.Errorf) {
+
+	// the parquet reader panics on encodings it does not support.
+	defer func() {
+		if r := recover(); r != nil {
+			cdcFile = nil
+			errf = &errs.Errorf{
+				Type:    errs.ErrInternalServer,
+				Message: fmt.Sprintf("Failed to read delta change data file : %v", r),
+			}
+		}
+	}()
+
+	cdcFile = &formats.DeltaCDCFile{
+		ChangeTypes: make(map[string]int64),
+		Rows:        make([]map[string]any, 0),
+	}
+
+	errf = countChangeTypes(filePath, cdcFile)
+	if errf != nil {
+		return nil, errf
+	}
+
+	errf = readCDCRows(filePath, rowsLimit, cdcFile)
+	if errf != nil {
+		return nil, errf
+	}
+
+	return cdcFile, nil
+}
+
+// countChangeTypes reads only the _change_type column of the file.
+func countChangeTypes(filePath string, cdcFile *formats.DeltaCDCFile) *errs.Errorf {
+
+	fileReader, err := local.NewLocalFileReader(filePath)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to open delta change data file : " + err.Error(),
+		}
+	}
+	defer fileReader.Close()
+
+	colReader, err := reader.NewParquetColumnReader(fileReader, 4)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to read delta change data file : " + err.Error(),
+		}
+	}
+	defer colReader.ReadStop()
+
+	cdcFile.NumRows = colReader.GetNumRows()
+
+	colPath := common.PathToStr([]string{colReader.SchemaHandler.GetRootExName(), ChangeTypeColumn})
+	values, _, _, err := colReader.ReadColumnByPath(colPath, cdcFile.NumRows)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Failed to read the _change_type column of the delta change data file : " + err.Error(),
+		}
+	}
+
+	for _, value := range values {
+		cdcFile.ChangeTypes[fmt.Sprintf("%v", value)]++
+	}
+
+	return nil
+}
+
+// readCDCRows reads the first rowsLimit rows of the file, keyed by their column names.
+func readCDCRows(filePath string, rowsLimit int, cdcFile *formats.DeltaCDCFile) *errs.Errorf {
+
+	if rowsLimit <= 0 || cdcFile.NumRows == 0 {
+		return nil
+	}
+
+	fileReader, err := local.NewLocalFileReader(filePath)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to open delta change data file : " + err.Error(),
+		}
+	}
+	defer fileReader.Close()
+
+	parqReader, err := reader.NewParquetReader(fileReader, nil, 4)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to read delta change data file : " + err.Error(),
+		}
+	}
+	defer parqReader.ReadStop()
+
+	// the dynamic rows use the (go) in names of the columns, like PARGO_PREFIX__change_type .
+	names := make(map[string]string, len(parqReader.SchemaHandler.Infos))
+	for _, info := range parqReader.SchemaHandler.Infos {
+		names[info.InName] = info.ExName
+	}
+
+	rows, err := parqReader.ReadByNumber(int(min(cdcFile.NumRows, int64(rowsLimit))))
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to read delta change data rows : " + err.Error(),
+		}
+	}
+
+	for _, row := range rows {
+		raw, err := json.Marshal(row)
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrInternalServer,
+				Message: "Failed to marshal delta change data row to json : " + err.Error(),
+			}
+		}
+
+		var values map[string]any
+		err = json.Unmarshal(raw, &values)
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrInternalServer,
+				Message: "Failed to un marshal delta change data row : " + err.Error(),
+			}
+		}
+
+		cdcFile.Rows = append(cdcFile.Rows, renameColumns(values, names))
+	}
+
+	return nil
+}
+
+// renameColumns renames the keys of the row (and its nested structs) back to the column names.
+func renameColumns(values map[string]any, names map[string]string) map[string]any {
+
+	renamed := make(map[string]any, len(values))
+	for key, value := range values {
+		if nested, ok := value.(map[string]any); ok {
+			value = renameColumns(nested, names)
+		}
+		if name, ok := names[key]; ok {
+			key = name
+		}
+		renamed[key] = value
+	}
+
+	return renamed
+}
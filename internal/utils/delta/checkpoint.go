@@ -0,0 +1,145 @@
+package deltautils
+
+import (
+	"encoding/json"
+	"fmt"
+	"lakelens/internal/consts/errs"
+	formats "lakelens/internal/dto/formats/delta"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// the number of checkpoint rows read at once.
+const checkpointBatchSize = 1000
+
+// ParseCheckpoint returns the version and part of the given checkpoint file, like
+// 00000000000000000010.checkpoint.parquet or 00000000000000000010.checkpoint.0000000001.0000000003.parquet .
+// parts is 1 for single file checkpoints. V2 (uuid named) checkpoints are not recognised, ok is false for them.
+func ParseCheckpoint(key string) (version, part, parts int64, ok bool) {
+
+	name, found := strings.CutSuffix(path.Base(key), ".parquet")
+	if !found {
+		return 0, 0, 0, false
+	}
+
+	segs := strings.Split(name, ".")
+	if len(segs) < 2 || segs[1] != "checkpoint" {
+		return 0, 0, 0, false
+	}
+
+	version, err := strconv.ParseInt(segs[0], 10, 64)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	switch len(segs) {
+	case 2:
+		return version, 1, 1, true
+	case 4:
+		part, err1 := strconv.ParseInt(segs[2], 10, 64)
+		parts, err2 := strconv.ParseInt(segs[3], 10, 64)
+		if err1 != nil || err2 != nil || part < 1 || part > parts {
+			return 0, 0, 0, false
+		}
+		return version, part, parts, true
+	}
+
+	return 0, 0, 0, false
+}
+
+// ReadLastCheckpoint reads and Unmarshals the given _last_checkpoint file.
+func ReadLastCheckpoint(filePath string) (*formats.DeltaLastCheckpoint, *errs.Errorf) {
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to read delta last checkpoint file : " + err.Error(),
+		}
+	}
+
+	last := new(formats.DeltaLastCheckpoint)
+	err = json.Unmarshal(data, last)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to un marshal delta last checkpoint to json : " + err.Error(),
+		}
+	}
+
+	return last, nil
+}
+
+// ReadCheckpoint reads all the actions of the given checkpoint parquet file into log.
+//
+// Every row holds a single action, the rows are matched to the JSON actions by their (case insensitive) names.
+func ReadCheckpoint(filePath string, log *formats.DeltaLog) (errf *errs.Errorf) {
+
+	fileReader, err := local.NewLocalFileReader(filePath)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to open delta checkpoint file : " + err.Error(),
+		}
+	}
+	defer fileReader.Close()
+
+	// the parquet reader panics on encodings it does not support.
+	defer func() {
+		if r := recover(); r != nil {
+			errf = &errs.Errorf{
+				Type:    errs.ErrInternalServer,
+				Message: fmt.Sprintf("Failed to read delta checkpoint file : %v", r),
+			}
+		}
+	}()
+
+	parqReader, err := reader.NewParquetReader(fileReader, nil, 4)
+	if err != nil {
+		return &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to read delta checkpoint file : " + err.Error(),
+		}
+	}
+	defer parqReader.ReadStop()
+
+	for remaining := parqReader.GetNumRows(); remaining > 0; remaining -= checkpointBatchSize {
+
+		rows, err := parqReader.ReadByNumber(int(min(remaining, checkpointBatchSize)))
+		if err != nil {
+			return &errs.Errorf{
+				Type:    errs.ErrInternalServer,
+				Message: "Failed to read delta checkpoint rows : " + err.Error(),
+			}
+		}
+
+		// a row that can not be read fails the whole checkpoint, dropping an action would corrupt the snapshot.
+		for _, row := range rows {
+			raw, err := json.Marshal(row)
+			if err != nil {
+				return &errs.Errorf{
+					Type:    errs.ErrInternalServer,
+					Message: "Failed to marshal delta checkpoint row to json : " + err.Error(),
+				}
+			}
+
+			var entry formats.DeltaLogSingle
+			err = json.Unmarshal(raw, &entry)
+			if err != nil {
+				return &errs.Errorf{
+					Type:    errs.ErrInternalServer,
+					Message: "Failed to un marshal delta checkpoint row : " + err.Error(),
+				}
+			}
+
+			addEntry(log, &entry)
+		}
+	}
+
+	return nil
+}
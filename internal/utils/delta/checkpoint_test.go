@@ -0,0 +1,77 @@
+package deltautils
+
+import "testing"
+
+func TestParseCheckpoint(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		key     string
+		version int64
+		part    int64
+		parts   int64
+		ok      bool
+	}{
+		{
+			name:    "single file",
+			key:     "tbl/_delta_log/00000000000000000010.checkpoint.parquet",
+			version: 10, part: 1, parts: 1, ok: true,
+		},
+		{
+			name:    "single file without folder",
+			key:     "00000000000000000000.checkpoint.parquet",
+			version: 0, part: 1, parts: 1, ok: true,
+		},
+		{
+			name:    "multi part",
+			key:     "tbl/_delta_log/00000000000000000020.checkpoint.0000000002.0000000003.parquet",
+			version: 20, part: 2, parts: 3, ok: true,
+		},
+		{
+			name:    "last part",
+			key:     "00000000000000000020.checkpoint.0000000003.0000000003.parquet",
+			version: 20, part: 3, parts: 3, ok: true,
+		},
+		{
+			name: "part after the total",
+			key:  "00000000000000000020.checkpoint.0000000004.0000000003.parquet",
+		},
+		{
+			name: "part zero",
+			key:  "00000000000000000020.checkpoint.0000000000.0000000003.parquet",
+		},
+		{
+			name: "v2 uuid checkpoint",
+			key:  "00000000000000000030.checkpoint.80a083e8-7026-4e79-81be-64bd76c43a11.parquet",
+		},
+		{
+			name: "json commit",
+			key:  "tbl/_delta_log/00000000000000000010.json",
+		},
+		{
+			name: "crc",
+			key:  "tbl/_delta_log/00000000000000000010.crc",
+		},
+		{
+			name: "non numeric version",
+			key:  "abc.checkpoint.parquet",
+		},
+		{
+			name: "data file",
+			key:  "tbl/part-00000-1f2e.snappy.parquet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, part, parts, ok := ParseCheckpoint(tt.key)
+			if ok != tt.ok {
+				t.Fatalf("ParseCheckpoint(%q) ok = %v, want %v", tt.key, ok, tt.ok)
+			}
+			if version != tt.version || part != tt.part || parts != tt.parts {
+				t.Errorf("ParseCheckpoint(%q) = (%d, %d, %d), want (%d, %d, %d)",
+					tt.key, version, part, parts, tt.version, tt.part, tt.parts)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package deltautils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"lakelens/internal/consts/errs"
+	formats "lakelens/internal/dto/formats/delta"
+	"os"
+	"strings"
+)
+
+// The deletion vector storage types.
+const (
+	DVStorageUUID   = "u"
+	DVStorageInline = "i"
+	DVStoragePath   = "p"
+)
+
+const (
+	// the magic number of a RoaringBitmapArray in the portable format, little endian.
+	dvPortableMagic = 1681511377
+
+	// the roaring bitmap (32 bit) serialization cookies.
+	roaringSerialCookieNoRun = 12346
+	roaringSerialCookie      = 12347
+
+	// the max cardinality of an array container, a bitmap container is stored above it.
+	roaringArrayMaxCard = 4096
+)
+
+const z85Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+var z85Decoder = func() [256]int16 {
+	var dec [256]int16
+	for i := range dec {
+		dec[i] = -1
+	}
+	for i := 0; i < len(z85Alphabet); i++ {
+		dec[z85Alphabet[i]] = int16(i)
+	}
+	return dec
+}()
+
+// z85Decode decodes the given Z85 (ZeroMQ base 85) string, its length must be a multiple of 5.
+func z85Decode(s string) ([]byte, error) {
+
+	if len(s)%5 != 0 {
+		return nil, fmt.Errorf("z85 length %d is not a multiple of 5", len(s))
+	}
+
+	out := make([]byte, 0, len(s)/5*4)
+	for i := 0; i < len(s); i += 5 {
+		var value uint32
+		for j := 0; j < 5; j++ {
+			d := z85Decoder[s[i+j]]
+			if d < 0 {
+				return nil, fmt.Errorf("invalid z85 character %q", s[i+j])
+			}
+			value = value*85 + uint32(d)
+		}
+		out = binary.BigEndian.AppendUint32(out, value)
+	}
+
+	return out, nil
+}
+
+// DVRelativePath returns the path, relative to the table root, of the file of the given uuid relative
+// deletion vector. The uuid is the last 20 (Z85) characters, anything before it is a folder prefix.
+func DVRelativePath(dv *formats.DeltaDeletionVector) (string, *errs.Errorf) {
+
+	encoded := dv.PathOrInlineDv
+	if len(encoded) < 20 {
+		return "", &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Delta deletion vector uuid path is too short : " + encoded,
+		}
+	}
+
+	prefix, encUUID := encoded[:len(encoded)-20], encoded[len(encoded)-20:]
+	raw, err := z85Decode(encUUID)
+	if err != nil {
+		return "", &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Failed to decode delta deletion vector uuid : " + err.Error(),
+		}
+	}
+
+	uuid := fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:16])
+	name := "deletion_vector_" + uuid + ".bin"
+	if prefix == "" {
+		return name, nil
+	}
+
+	return strings.TrimSuffix(prefix, "/") + "/" + name, nil
+}
+
+// InlineDVCardinality decodes the given inline deletion vector and returns the number of rows it deletes.
+func InlineDVCardinality(dv *formats.DeltaDeletionVector) (int64, *errs.Errorf) {
+
+	data, err := z85Decode(dv.PathOrInlineDv)
+	if err != nil {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Failed to decode inline delta deletion vector : " + err.Error(),
+		}
+	}
+	// the encoding pads the data to a multiple of 4 bytes.
+	if dv.SizeInBytes > 0 && dv.SizeInBytes <= int64(len(data)) {
+		data = data[:dv.SizeInBytes]
+	}
+
+	return bitmapArrayCardinality(data)
+}
+
+// ReadDVCardinality reads the deletion vector at the given offset of the downloaded deletion vector file
+// and returns the number of rows it deletes.
+//
+// At the offset, the bitmap is prefixed by its size and followed by its crc32 checksum (both big endian).
+func ReadDVCardinality(filePath string, offset int64) (int64, *errs.Errorf) {
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to read delta deletion vector file : " + err.Error(),
+		}
+	}
+
+	if offset < 0 || offset+4 > int64(len(data)) {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: fmt.Sprintf("Delta deletion vector offset %d is out of the file (%d bytes).", offset, len(data)),
+		}
+	}
+
+	size := int64(binary.BigEndian.Uint32(data[offset:]))
+	start, end := offset+4, offset+4+size
+	if end+4 > int64(len(data)) {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: fmt.Sprintf("Delta deletion vector of %d bytes at offset %d is out of the file (%d bytes).", size, offset, len(data)),
+		}
+	}
+
+	bitmap := data[start:end]
+	if crc32.ChecksumIEEE(bitmap) != binary.BigEndian.Uint32(data[end:]) {
+		return 0, &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Delta deletion vector checksum mismatch.",
+		}
+	}
+
+	return bitmapArrayCardinality(bitmap)
+}
+
+// bitmapArrayCardinality returns the cardinality of the given serialized RoaringBitmapArray (portable format),
+// a magic number, the number of 32 bit bitmaps and then every bitmap prefixed by its key (the high 32 bits).
+func bitmapArrayCardinality(data []byte) (int64, *errs.Errorf) {
+
+	malformed := func(reason string) *errs.Errorf {
+		return &errs.Errorf{
+			Type:    errs.ErrInvalidFormat,
+			Message: "Malformed delta deletion vector bitmap : " + reason,
+		}
+	}
+
+	if len(data) < 12 {
+		return 0, malformed("too short")
+	}
+	if binary.LittleEndian.Uint32(data) != dvPortableMagic {
+		return 0, malformed("unsupported magic number, only the portable format is read")
+	}
+
+	count := binary.LittleEndian.Uint64(data[4:])
+	pos := 12
+
+	var total int64
+	for i := uint64(0); i < count; i++ {
+		if pos+4 > len(data) {
+			return 0, malformed("truncated bitmap key")
+		}
+		pos += 4
+
+		card, n, err := roaringCardinality(data[pos:])
+		if err != nil {
+			return 0, malformed(err.Error())
+		}
+		total += card
+		pos += n
+	}
+
+	return total, nil
+}
+
+// roaringCardinality returns the cardinality of the 32 bit roaring bitmap (portable serialization) at the
+// start of data, and the number of bytes it takes.
+func roaringCardinality(data []byte) (int64, int, error) {
+
+	if len(data) < 4 {
+		return 0, 0, fmt.Errorf("truncated roaring cookie")
+	}
+
+	cookie := binary.LittleEndian.Uint32(data)
+	pos := 4
+
+	var size int
+	var runs []byte
+	hasOffsets := true
+
+	switch {
+	case cookie&0xFFFF == roaringSerialCookie:
+		size = int(cookie>>16) + 1
+		runLen := (size + 7) / 8
+		if pos+runLen > len(data) {
+			return 0, 0, fmt.Errorf("truncated roaring run flags")
+		}
+		runs = data[pos : pos+runLen]
+		pos += runLen
+		hasOffsets = size >= 4
+	case cookie == roaringSerialCookieNoRun:
+		if pos+4 > len(data) {
+			return 0, 0, fmt.Errorf("truncated roaring size")
+		}
+		size = int(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+	default:
+		return 0, 0, fmt.Errorf("unknown roaring cookie %d", cookie)
+	}
+
+	if pos+size*4 > len(data) {
+		return 0, 0, fmt.Errorf("truncated roaring headers")
+	}
+	cards := make([]int, size)
+	for i := 0; i < size; i++ {
+		// key (uint16), then cardinality - 1 (uint16).
+		cards[i] = int(binary.LittleEndian.Uint16(data[pos+i*4+2:])) + 1
+	}
+	pos += size * 4
+
+	if hasOffsets {
+		pos += size * 4
+	}
+
+	var total int64
+	for i, card := range cards {
+		var n int
+		switch {
+		case runs != nil && runs[i/8]&(1<<(i%8)) != 0:
+			if pos+2 > len(data) {
+				return 0, 0, fmt.Errorf("truncated roaring run container")
+			}
+			n = 2 + int(binary.LittleEndian.Uint16(data[pos:]))*4
+		case card <= roaringArrayMaxCard:
+			n = card * 2
+		default:
+			n = 8192
+		}
+
+		if pos+n > len(data) {
+			return 0, 0, fmt.Errorf("truncated roaring container")
+		}
+		pos += n
+		total += int64(card)
+	}
+
+	return total, pos, nil
+}
@@ -0,0 +1,330 @@
+package deltautils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	formats "lakelens/internal/dto/formats/delta"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// z85Encode is the inverse of z85Decode, data is padded with zeros to a multiple of 4 bytes.
+func z85Encode(data []byte) string {
+
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+
+	var out []byte
+	for i := 0; i < len(data); i += 4 {
+		value := binary.BigEndian.Uint32(data[i:])
+		var chunk [5]byte
+		for j := 4; j >= 0; j-- {
+			chunk[j] = z85Alphabet[value%85]
+			value /= 85
+		}
+		out = append(out, chunk[:]...)
+	}
+
+	return string(out)
+}
+
+// roaringArray serializes a RoaringBitmapArray (portable format) of the given 32 bit bitmaps.
+func roaringArray(bitmaps ...[]byte) []byte {
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(dvPortableMagic))
+	binary.Write(&buf, binary.LittleEndian, uint64(len(bitmaps)))
+	for i, bitmap := range bitmaps {
+		binary.Write(&buf, binary.LittleEndian, uint32(i))
+		buf.Write(bitmap)
+	}
+
+	return buf.Bytes()
+}
+
+// arrayBitmap is a no run roaring bitmap with a single array (card <= 4096) or bitmap container.
+func arrayBitmap(card int) []byte {
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(roaringSerialCookieNoRun))
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{0, uint16(card - 1)})
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // the container offset.
+	if card <= roaringArrayMaxCard {
+		for v := 0; v < card; v++ {
+			binary.Write(&buf, binary.LittleEndian, uint16(v))
+		}
+	} else {
+		buf.Write(make([]byte, 8192))
+	}
+
+	return buf.Bytes()
+}
+
+// runBitmap is a roaring bitmap with a single run container of the values [0, card).
+func runBitmap(card int) []byte {
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint32(roaringSerialCookie)) // size - 1 = 0 in the high bits.
+	buf.WriteByte(1)                                                     // the run flags, the container is a run.
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{0, uint16(card - 1)})
+	binary.Write(&buf, binary.LittleEndian, uint16(1))                      // the number of runs.
+	binary.Write(&buf, binary.LittleEndian, [2]uint16{0, uint16(card - 1)}) // start, length - 1.
+
+	return buf.Bytes()
+}
+
+func TestZ85Decode(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:  "zeromq spec vector",
+			input: "HelloWorld",
+			want:  []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B},
+		},
+		{
+			name:  "empty",
+			input: "",
+			want:  []byte{},
+		},
+		{
+			name:  "zeros",
+			input: "00000",
+			want:  []byte{0, 0, 0, 0},
+		},
+		{
+			name:    "length not a multiple of 5",
+			input:   "Hello1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			input:   "Hell~",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := z85Decode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("z85Decode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(got, tt.want) {
+				t.Errorf("z85Decode(%q) = %x, want %x", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDVRelativePath(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		encoded string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "protocol example with prefix",
+			encoded: "ab^-aqEH.-t@S}K{vb[*k^",
+			want:    "ab/deletion_vector_d2c639aa-8816-431a-aaf6-d3fe2512ff61.bin",
+		},
+		{
+			name:    "no prefix",
+			encoded: "^-aqEH.-t@S}K{vb[*k^",
+			want:    "deletion_vector_d2c639aa-8816-431a-aaf6-d3fe2512ff61.bin",
+		},
+		{
+			name:    "too short",
+			encoded: "ab^-aq",
+			wantErr: true,
+		},
+		{
+			name:    "invalid character",
+			encoded: "~~~~~~~~~~~~~~~~~~~~",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errf := DVRelativePath(&formats.DeltaDeletionVector{PathOrInlineDv: tt.encoded})
+			if (errf != nil) != tt.wantErr {
+				t.Fatalf("DVRelativePath(%q) error = %v, wantErr %v", tt.encoded, errf, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DVRelativePath(%q) = %q, want %q", tt.encoded, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBitmapArrayCardinality(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "empty array",
+			data: roaringArray(),
+			want: 0,
+		},
+		{
+			name: "array container",
+			data: roaringArray(arrayBitmap(3)),
+			want: 3,
+		},
+		{
+			name: "bitmap container",
+			data: roaringArray(arrayBitmap(5000)),
+			want: 5000,
+		},
+		{
+			name: "run container",
+			data: roaringArray(runBitmap(100)),
+			want: 100,
+		},
+		{
+			name: "several bitmaps",
+			data: roaringArray(arrayBitmap(3), runBitmap(100), arrayBitmap(4097)),
+			want: 3 + 100 + 4097,
+		},
+		{
+			name:    "too short",
+			data:    []byte{1, 2, 3},
+			wantErr: true,
+		},
+		{
+			name:    "unknown magic",
+			data:    append([]byte{0, 0, 0, 0}, roaringArray()[4:]...),
+			wantErr: true,
+		},
+		{
+			name:    "truncated container",
+			data:    roaringArray(arrayBitmap(3))[:12+4+16+4],
+			wantErr: true,
+		},
+		{
+			name:    "missing bitmap",
+			data:    roaringArray(arrayBitmap(3))[:12],
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errf := bitmapArrayCardinality(tt.data)
+			if (errf != nil) != tt.wantErr {
+				t.Fatalf("bitmapArrayCardinality() error = %v, wantErr %v", errf, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("bitmapArrayCardinality() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineDVCardinality(t *testing.T) {
+
+	data := roaringArray(arrayBitmap(3), runBitmap(10))
+
+	tests := []struct {
+		name    string
+		dv      *formats.DeltaDeletionVector
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "padded to 4 bytes",
+			dv: &formats.DeltaDeletionVector{
+				StorageType:    DVStorageInline,
+				PathOrInlineDv: z85Encode(data),
+				SizeInBytes:    int64(len(data)),
+			},
+			want: 13,
+		},
+		{
+			name: "invalid encoding",
+			dv: &formats.DeltaDeletionVector{
+				StorageType:    DVStorageInline,
+				PathOrInlineDv: "abcd",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errf := InlineDVCardinality(tt.dv)
+			if (errf != nil) != tt.wantErr {
+				t.Fatalf("InlineDVCardinality() error = %v, wantErr %v", errf, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("InlineDVCardinality() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadDVCardinality(t *testing.T) {
+
+	bitmap := roaringArray(arrayBitmap(7))
+
+	// a version byte, then the size prefixed bitmap and its checksum.
+	var file bytes.Buffer
+	file.WriteByte(1)
+	binary.Write(&file, binary.BigEndian, uint32(len(bitmap)))
+	file.Write(bitmap)
+	binary.Write(&file, binary.BigEndian, crc32.ChecksumIEEE(bitmap))
+
+	corrupt := bytes.Clone(file.Bytes())
+	corrupt[len(corrupt)-1] ^= 0xFF
+
+	dir := t.TempDir()
+	goodPath := filepath.Join(dir, "good.bin")
+	corruptPath := filepath.Join(dir, "corrupt.bin")
+	if err := os.WriteFile(goodPath, file.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(corruptPath, corrupt, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		offset   int64
+		want     int64
+		wantErr  bool
+	}{
+		{name: "valid", filePath: goodPath, offset: 1, want: 7},
+		{name: "checksum mismatch", filePath: corruptPath, offset: 1, wantErr: true},
+		{name: "offset out of the file", filePath: goodPath, offset: int64(file.Len()), wantErr: true},
+		{name: "negative offset", filePath: goodPath, offset: -1, wantErr: true},
+		{name: "missing file", filePath: filepath.Join(dir, "missing.bin"), offset: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errf := ReadDVCardinality(tt.filePath, tt.offset)
+			if (errf != nil) != tt.wantErr {
+				t.Fatalf("ReadDVCardinality() error = %v, wantErr %v", errf, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadDVCardinality() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package deltautils
+
+import (
+	formats "lakelens/internal/dto/formats/delta"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Feature is a delta table feature, as named in the protocol reader/writer features.
+type Feature struct {
+	Name        string
+	Reader      bool // a reader feature is required to read the table, the others only to write it.
+	Description string
+
+	// the first Delta Lake (Spark) release able to read a table using it, empty if readers need no support.
+	DeltaSparkReader string
+}
+
+// Features lists the known table features.
+//
+// The engine support is hand maintained from the Delta Lake release notes, it is best effort and may lag behind.
+var Features = map[string]Feature{
+	"appendOnly":                 {Name: "appendOnly", Description: "Rows can only be appended, updates and deletes are rejected."},
+	"invariants":                 {Name: "invariants", Description: "Column invariants (legacy SQL constraints) are enforced on writes."},
+	"checkConstraints":           {Name: "checkConstraints", Description: "CHECK constraints are enforced on writes."},
+	"changeDataFeed":             {Name: "changeDataFeed", Description: "Row level changes are recorded as change data files."},
+	"generatedColumns":           {Name: "generatedColumns", Description: "Some columns are generated from the other columns."},
+	"columnMapping":              {Name: "columnMapping", Reader: true, Description: "Columns are mapped to physical names or ids, allowing renames and drops.", DeltaSparkReader: "1.2.0"},
+	"identityColumns":            {Name: "identityColumns", Description: "Identity columns generate unique increasing values."},
+	"deletionVectors":            {Name: "deletionVectors", Reader: true, Description: "Rows are soft deleted with deletion vectors instead of rewriting the files.", DeltaSparkReader: "2.3.0"},
+	"timestampNtz":               {Name: "timestampNtz", Reader: true, Description: "Columns of the timestamp without time zone type.", DeltaSparkReader: "2.4.0"},
+	"domainMetadata":             {Name: "domainMetadata", Description: "Domain specific metadata is kept in the log."},
+	"v2Checkpoint":               {Name: "v2Checkpoint", Reader: true, Description: "Checkpoints are uuid named and may use sidecar files.", DeltaSparkReader: "3.0.0"},
+	"icebergCompatV1":            {Name: "icebergCompatV1", Description: "The table is kept readable by Iceberg clients (UniForm)."},
+	"icebergCompatV2":            {Name: "icebergCompatV2", Description: "The table is kept readable by Iceberg clients (UniForm)."},
+	"clustering":                 {Name: "clustering", Description: "The files are clustered by the liquid clustering columns."},
+	"rowTracking":                {Name: "rowTracking", Description: "Rows get stable ids and commit versions."},
+	"inCommitTimestamp":          {Name: "inCommitTimestamp", Description: "Commit timestamps are written in the commits, they are monotonic."},
+	"vacuumProtocolCheck":        {Name: "vacuumProtocolCheck", Reader: true, Description: "Vacuum checks the protocol before deleting files.", DeltaSparkReader: "3.3.0"},
+	"typeWidening":               {Name: "typeWidening", Reader: true, Description: "Column types can be widened without rewriting the files.", DeltaSparkReader: "4.0.0"},
+	"typeWidening-preview":       {Name: "typeWidening-preview", Reader: true, Description: "Preview of the type widening feature.", DeltaSparkReader: "3.2.0"},
+	"variantType":                {Name: "variantType", Reader: true, Description: "Columns of the semi structured variant type.", DeltaSparkReader: "4.0.0"},
+	"variantType-preview":        {Name: "variantType-preview", Reader: true, Description: "Preview of the variant type.", DeltaSparkReader: "3.2.0"},
+	"allowColumnDefaults":        {Name: "allowColumnDefaults", Description: "Columns can have default values."},
+	"collations-preview":         {Name: "collations-preview", Description: "String columns can have collations."},
+	"checkpointProtection":       {Name: "checkpointProtection", Description: "Checkpoints are protected from the log cleanup."},
+	"catalogOwned-preview":       {Name: "catalogOwned-preview", Reader: true, Description: "Commits are coordinated by the catalog.", DeltaSparkReader: "4.0.0"},
+	"coordinatedCommits-preview": {Name: "coordinatedCommits-preview", Description: "Commits are coordinated by an external commit coordinator."},
+}
+
+// DeltaSparkReleases are the Delta Lake (Spark) releases the reader support is reported for.
+var DeltaSparkReleases = []string{"1.0.0", "1.2.0", "2.0.0", "2.2.0", "2.3.0", "2.4.0", "3.0.0", "3.1.0", "3.2.0", "3.3.0", "4.0.0"}
+
+// the first Delta Lake (Spark) release supporting each reader version.
+var readerVersionReleases = map[int64]string{
+	1: "0.1.0",
+	2: "1.2.0",
+	3: "2.3.0",
+}
+
+// MinDeltaSparkReader returns the first Delta Lake (Spark) release able to read a table of the given protocol,
+// and the required reader features whose support is not known.
+func MinDeltaSparkReader(protocol formats.DeltaProtocol) (string, []string) {
+
+	release := readerVersionReleases[max(protocol.MinReaderVersion, 1)]
+	unknown := make([]string, 0)
+
+	if protocol.MinReaderVersion >= 3 {
+		for _, name := range protocol.ReaderFeatures {
+			feature, ok := Features[name]
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			if CompareReleases(feature.DeltaSparkReader, release) > 0 {
+				release = feature.DeltaSparkReader
+			}
+		}
+	}
+
+	return release, unknown
+}
+
+// legacy protocol versions implicitly enable the features below, before table features (reader 3, writer 7).
+var legacyReaderFeatures = map[int64][]string{
+	2: {"columnMapping"},
+}
+var legacyWriterFeatures = map[int64][]string{
+	2: {"appendOnly", "invariants"},
+	3: {"checkConstraints"},
+	4: {"changeDataFeed", "generatedColumns"},
+	5: {"columnMapping"},
+	6: {"identityColumns"},
+}
+
+// RequiredFeatures returns the features the protocol requires, sorted by name. The legacy protocols (writer
+// version below 7) support every feature of their version, the table may not actually use them.
+func RequiredFeatures(protocol formats.DeltaProtocol) []string {
+
+	set := make(map[string]bool)
+	for _, name := range protocol.ReaderFeatures {
+		set[name] = true
+	}
+	for _, name := range protocol.WriterFeatures {
+		set[name] = true
+	}
+
+	if protocol.MinReaderVersion < 3 {
+		for version, names := range legacyReaderFeatures {
+			if version <= protocol.MinReaderVersion {
+				for _, name := range names {
+					set[name] = true
+				}
+			}
+		}
+	}
+	if protocol.MinWriterVersion < 7 {
+		for version, names := range legacyWriterFeatures {
+			if version <= protocol.MinWriterVersion {
+				for _, name := range names {
+					set[name] = true
+				}
+			}
+		}
+	}
+
+	features := make([]string, 0, len(set))
+	for name := range set {
+		features = append(features, name)
+	}
+	slices.Sort(features)
+
+	return features
+}
+
+// CompareReleases compares two dotted release versions, like 3.2.0 and 3.10.1 .
+func CompareReleases(a, b string) int {
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int64
+		if i < len(as) {
+			x, _ = strconv.ParseInt(as[i], 10, 64)
+		}
+		if i < len(bs) {
+			y, _ = strconv.ParseInt(bs[i], 10, 64)
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -22,8 +22,11 @@ func ReadMetadata(filePath string) (*formats.DeltaLog, *errs.Errorf) {
 			Message: "Failed to read iceberg metadata file : " + err.Error(),
 		}
 	}
+	defer data.Close()
 
 	scanner := bufio.NewScanner(data)
+	// the metadata (schema) and add (stats) lines can get long.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
 	for scanner.Scan() {
 		var entry formats.DeltaLogSingle
 		err := json.Unmarshal(scanner.Bytes(), &entry)
@@ -32,30 +35,44 @@ func ReadMetadata(filePath string) (*formats.DeltaLog, *errs.Errorf) {
 			continue
 		}
 
-		switch {
-			case entry.CommitInfo != nil:
-				log.CommitInfo = *entry.CommitInfo
-			case entry.Metadata != nil:
-				log.Metadata = *entry.Metadata
-
-				log.Metadata.Schema = *unmarshalSchema(entry.Metadata.SchemaString)
-
-			case entry.Protocol != nil:
-				log.Protocol = *entry.Protocol
-			case entry.Transaction != nil:
-				log.Transaction = *entry.Transaction
-			case entry.Add != nil:
-				log.Add = append(log.Add, *entry.Add)
-			case entry.Remove != nil:
-				log.Remove = append(log.Remove, *entry.Remove)
-			default:
-				fmt.Println("none")
+		addEntry(log, &entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to read delta log file : " + err.Error(),
 		}
 	}
 
 	return log, nil
 }
 
+// addEntry adds the single action of the given entry to the log.
+func addEntry(log *formats.DeltaLog, entry *formats.DeltaLogSingle) {
+
+	switch {
+	case entry.CommitInfo != nil:
+		log.CommitInfo = *entry.CommitInfo
+	case entry.Metadata != nil:
+		log.Metadata = *entry.Metadata
+
+		if schema := unmarshalSchema(entry.Metadata.SchemaString); schema != nil {
+			log.Metadata.Schema = *schema
+		}
+
+	case entry.Protocol != nil:
+		log.Protocol = *entry.Protocol
+	case entry.Transaction != nil:
+		log.Transaction = *entry.Transaction
+	case entry.Add != nil:
+		log.Add = append(log.Add, *entry.Add)
+	case entry.Remove != nil:
+		log.Remove = append(log.Remove, *entry.Remove)
+	case entry.CDC != nil:
+		log.CDC = append(log.CDC, *entry.CDC)
+	}
+}
+
 func unmarshalSchema(schemaStr string) (*formats.DeltaSchema) {
 
 	var schema formats.DeltaSchema
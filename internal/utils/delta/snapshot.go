@@ -0,0 +1,95 @@
+package deltautils
+
+import (
+	formats "lakelens/internal/dto/formats/delta"
+)
+
+// NewSnapshot returns an empty snapshot, before version 0.
+func NewSnapshot() *formats.DeltaSnapshot {
+	return &formats.DeltaSnapshot{
+		Version: -1,
+		Files:   make(map[string]*formats.DeltaFile),
+	}
+}
+
+// ApplyLog applies the actions of the given commit (or checkpoint) to the snapshot, moving it to the log version.
+//
+// The logs must be applied in version order, starting from a checkpoint or version 0.
+func ApplyLog(snap *formats.DeltaSnapshot, log *formats.DeltaLog) {
+
+	snap.Version = log.Version
+	snap.Timestamp = CommitTimestamp(log)
+	snap.RecordsDelta = 0
+
+	if log.Protocol.MinReaderVersion != 0 || log.Protocol.MinWriterVersion != 0 {
+		snap.Protocol = log.Protocol
+	}
+	if log.Metadata.SchemaString != "" {
+		snap.Metadata = log.Metadata
+	}
+
+	for _, rm := range log.Remove {
+		removeFile(snap, rm.Path)
+	}
+
+	for _, add := range log.Add {
+		// re-adding a path replaces the file, like with an updated deletion vector.
+		removeFile(snap, add.Path)
+
+		file := &formats.DeltaFile{
+			Add: add,
+		}
+		if stats, errf := ParseStats(add.Stats); errf == nil && stats != nil {
+			file.NumRecords = stats.NumRecords
+			file.HasStats = true
+		} else {
+			snap.NoStats++
+		}
+		if add.DeletionVector != nil {
+			file.DeletedRows = add.DeletionVector.Cardinality
+		}
+
+		snap.Files[add.Path] = file
+		snap.SizeInBytes += add.Size
+		snap.NumRecords += liveRecords(file)
+		snap.DeletedRows += file.DeletedRows
+		snap.RecordsDelta += liveRecords(file)
+	}
+}
+
+func removeFile(snap *formats.DeltaSnapshot, filePath string) {
+
+	file, ok := snap.Files[filePath]
+	if !ok {
+		return
+	}
+
+	if !file.HasStats {
+		snap.NoStats--
+	}
+	snap.SizeInBytes -= file.Add.Size
+	snap.NumRecords -= liveRecords(file)
+	snap.DeletedRows -= file.DeletedRows
+	snap.RecordsDelta -= liveRecords(file)
+	delete(snap.Files, filePath)
+}
+
+// liveRecords returns the records of the file not deleted by its deletion vector, 0 without stats.
+func liveRecords(file *formats.DeltaFile) int64 {
+
+	if !file.HasStats {
+		return 0
+	}
+
+	return file.NumRecords - file.DeletedRows
+}
+
+// CommitTimestamp returns the in-commit timestamp of the commit if set, else the commit info timestamp.
+func CommitTimestamp(log *formats.DeltaLog) int64 {
+
+	if log.CommitInfo.InCommitTS != 0 {
+		return log.CommitInfo.InCommitTS
+	}
+
+	return log.CommitInfo.Timestamp
+}
@@ -0,0 +1,180 @@
+package deltautils
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"lakelens/internal/consts/errs"
+	formats "lakelens/internal/dto/formats/delta"
+	"strconv"
+	"strings"
+)
+
+// ParseStats Unmarshals the stats JSON of a single add action, an empty string returns nil stats.
+func ParseStats(raw string) (*formats.DeltaStats, *errs.Errorf) {
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	stats := new(formats.DeltaStats)
+	err := json.Unmarshal([]byte(raw), stats)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to un marshal delta file stats to json : " + err.Error(),
+		}
+	}
+
+	return stats, nil
+}
+
+// rawFileStats is the stats JSON with the numbers kept as is, to not lose the precision of longs.
+type rawFileStats struct {
+	NumRecords  json.Number    `json:"numRecords"`
+	TightBounds *bool          `json:"tightBounds"`
+	MinValues   map[string]any `json:"minValues"`
+	MaxValues   map[string]any `json:"maxValues"`
+	NullCount   map[string]any `json:"nullCount"`
+}
+
+// ParseFileStats parses the stats JSON of a single add action against the given schema, nested struct
+// columns are flattened to their dotted paths. An empty string returns nil stats.
+func ParseFileStats(raw string, schema formats.DeltaSchema) (*formats.DeltaFileStats, *errs.Errorf) {
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	var rawStats rawFileStats
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	err := decoder.Decode(&rawStats)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to un marshal delta file stats to json : " + err.Error(),
+		}
+	}
+
+	stats := &formats.DeltaFileStats{
+		TightBounds: rawStats.TightBounds,
+		Columns:     make(map[string]*formats.DeltaColumnStats),
+	}
+	stats.NumRecords, _ = rawStats.NumRecords.Int64()
+
+	walkStats(schema.Fields, "", rawStats.MinValues, rawStats.MaxValues, rawStats.NullCount, stats.Columns)
+
+	return stats, nil
+}
+
+// walkStats adds the stats of the given fields (and the fields of nested structs) to columns.
+func walkStats(fields []formats.DeltaSchemaField, prefix string, mins, maxs, nulls map[string]any, columns map[string]*formats.DeltaColumnStats) {
+
+	for _, field := range fields {
+		key := PhysicalName(field)
+		path := prefix + field.Name
+
+		if nested := StructFields(field.Type); nested != nil {
+			subMins, _ := mins[key].(map[string]any)
+			subMaxs, _ := maxs[key].(map[string]any)
+			subNulls, _ := nulls[key].(map[string]any)
+			walkStats(nested, path+".", subMins, subMaxs, subNulls, columns)
+			continue
+		}
+
+		typ := TypeString(field.Type)
+		col := &formats.DeltaColumnStats{
+			Path: path,
+			Type: typ,
+			Min:  typedValue(typ, mins[key]),
+			Max:  typedValue(typ, maxs[key]),
+		}
+		if count, ok := nulls[key].(json.Number); ok {
+			col.NullCount, _ = count.Int64()
+			col.HasNullCount = true
+		}
+
+		if col.Min == nil && col.Max == nil && !col.HasNullCount {
+			continue
+		}
+		columns[path] = col
+	}
+}
+
+// typedValue converts a single min/max value to the go type of the given delta type, nil if not set.
+func typedValue(typ string, value any) any {
+
+	if value == nil {
+		return nil
+	}
+
+	num, isNum := value.(json.Number)
+
+	switch typ {
+	case "byte", "short", "integer", "long":
+		if isNum {
+			if v, err := num.Int64(); err == nil {
+				return v
+			}
+		}
+	case "float", "double":
+		if isNum {
+			if v, err := num.Float64(); err == nil {
+				return v
+			}
+		}
+	case "boolean":
+		if v, ok := value.(bool); ok {
+			return v
+		}
+	}
+
+	if isNum {
+		return num.String()
+	}
+	if v, ok := value.(string); ok {
+		return v
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// CompareValues compares two typed min/max values of a column of the given type, decimals (kept as
+// strings) are compared by their numeric value.
+func CompareValues(typ string, a, b any) int {
+
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case !a:
+				return -1
+			}
+			return 1
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			if strings.HasPrefix(typ, "decimal") {
+				fa, errA := strconv.ParseFloat(a, 64)
+				fb, errB := strconv.ParseFloat(b, 64)
+				if errA == nil && errB == nil {
+					return cmp.Compare(fa, fb)
+				}
+			}
+			return strings.Compare(a, b)
+		}
+	}
+
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
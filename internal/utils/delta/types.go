@@ -0,0 +1,69 @@
+package deltautils
+
+import (
+	"encoding/json"
+	"fmt"
+	formats "lakelens/internal/dto/formats/delta"
+	"strings"
+)
+
+// TypeString returns the given schema type as a string, nested types are rendered like
+// struct<a:int,b:array<string>> and map<string,long>.
+func TypeString(t any) string {
+
+	switch t := t.(type) {
+	case string:
+		return t
+	case map[string]any:
+		switch t["type"] {
+		case "struct":
+			fields := StructFields(t)
+			parts := make([]string, 0, len(fields))
+			for _, field := range fields {
+				parts = append(parts, field.Name+":"+TypeString(field.Type))
+			}
+			return "struct<" + strings.Join(parts, ",") + ">"
+		case "array":
+			return "array<" + TypeString(t["elementType"]) + ">"
+		case "map":
+			return "map<" + TypeString(t["keyType"]) + "," + TypeString(t["valueType"]) + ">"
+		}
+	}
+
+	return fmt.Sprintf("%v", t)
+}
+
+// StructFields returns the fields of the given struct type, nil for any other type.
+func StructFields(t any) []formats.DeltaSchemaField {
+
+	nested, ok := t.(map[string]any)
+	if !ok || nested["type"] != "struct" {
+		return nil
+	}
+
+	raw, err := json.Marshal(nested)
+	if err != nil {
+		return nil
+	}
+
+	var schema formats.DeltaSchema
+	err = json.Unmarshal(raw, &schema)
+	if err != nil {
+		return nil
+	}
+
+	return schema.Fields
+}
+
+// PhysicalName returns the name the field is stored (and its stats are kept) under, which differs
+// from its name with column mapping.
+func PhysicalName(field formats.DeltaSchemaField) string {
+
+	if meta, ok := field.Metadata.(map[string]any); ok {
+		if name, ok := meta["delta.columnMapping.physicalName"].(string); ok && name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
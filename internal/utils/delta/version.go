@@ -0,0 +1,30 @@
+package deltautils
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ParseVersion returns the version of the given commit file (like _delta_log/00000000000000000010.json).
+// ok is false for any other file in the log folder.
+func ParseVersion(key string) (int64, bool) {
+
+	name, ok := strings.CutSuffix(path.Base(key), ".json")
+	if !ok || name == "" {
+		return 0, false
+	}
+
+	for _, c := range name {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+
+	version, err := strconv.ParseInt(name, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
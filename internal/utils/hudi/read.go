@@ -0,0 +1,154 @@
+package hudiutils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"lakelens/internal/consts/errs"
+	formats "lakelens/internal/dto/formats/hudi"
+	"os"
+	"strings"
+)
+
+// the magic bytes of avro object container files, hudi 1.x serializes all instants as these.
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// ReadProperties reads and parses the given hoodie.properties file (java properties format).
+func ReadProperties(filePath string) (*formats.HudiProperties, *errs.Errorf) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to open hudi properties file : " + err.Error(),
+		}
+	}
+	defer file.Close()
+
+	raw := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	// the create schema is a single (long) line.
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		key, value := splitProperty(line)
+		raw[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to read hudi properties file : " + err.Error(),
+		}
+	}
+
+	return &formats.HudiProperties{
+		TableName:          raw["hoodie.table.name"],
+		DatabaseName:       raw["hoodie.database.name"],
+		TableType:          raw["hoodie.table.type"],
+		TableVersion:       raw["hoodie.table.version"],
+		TimelineLayout:     raw["hoodie.timeline.layout.version"],
+		BaseFileFormat:     raw["hoodie.table.base.file.format"],
+		RecordKeyFields:    splitList(raw["hoodie.table.recordkey.fields"]),
+		PrecombineField:    raw["hoodie.table.precombine.field"],
+		PartitionFields:    splitList(raw["hoodie.table.partition.fields"]),
+		KeyGenerator:       raw["hoodie.table.keygenerator.class"],
+		CreateSchema:       raw["hoodie.table.create.schema"],
+		MetadataPartitions: splitList(raw["hoodie.table.metadata.partitions"]),
+		Raw:                raw,
+	}, nil
+}
+
+// splitProperty splits a single properties line at the first unescaped '=' or ':' and unescapes both sides.
+func splitProperty(line string) (string, string) {
+
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '=', ':':
+			return unescape(strings.TrimSpace(line[:i])), unescape(strings.TrimSpace(line[i+1:]))
+		}
+	}
+
+	return unescape(line), ""
+}
+
+func unescape(s string) string {
+
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+
+	return b.String()
+}
+
+func splitList(s string) []string {
+
+	list := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// ReadCommitMetadata reads and Unmarshals the given completed commit, deltacommit or replacecommit instant file.
+//
+// Only the JSON instants of hudi 0.x are supported, an empty file returns empty metadata.
+func ReadCommitMetadata(filePath string) (*formats.HudiCommitMetadata, *errs.Errorf) {
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrStorageFailed,
+			Message: "Failed to read hudi commit file : " + err.Error(),
+		}
+	}
+
+	commit := new(formats.HudiCommitMetadata)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return commit, nil
+	}
+
+	if bytes.HasPrefix(data, avroMagic) {
+		return nil, &errs.Errorf{
+			Type:      errs.ErrInvalidFormat,
+			Message:   "Hudi commit metadata is avro serialized (timeline layout 2), only JSON commits are read for now.",
+			ReturnRaw: true,
+		}
+	}
+
+	err = json.Unmarshal(data, commit)
+	if err != nil {
+		return nil, &errs.Errorf{
+			Type:    errs.ErrInternalServer,
+			Message: "Failed to un marshal hudi commit to json : " + err.Error(),
+		}
+	}
+
+	return commit, nil
+}
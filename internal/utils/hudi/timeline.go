@@ -0,0 +1,96 @@
+package hudiutils
+
+import (
+	formats "lakelens/internal/dto/formats/hudi"
+	"strings"
+)
+
+// The instant states, a completed instant has no state suffix in its file name.
+const (
+	StateRequested = "requested"
+	StateInflight  = "inflight"
+	StateCompleted = "completed"
+)
+
+// the timeline actions that are recognised, anything else in the timeline folder is ignored.
+var actions = map[string]bool{
+	"commit":        true,
+	"deltacommit":   true,
+	"replacecommit": true,
+	"clean":         true,
+	"compaction":    true,
+	"logcompaction": true,
+	"rollback":      true,
+	"savepoint":     true,
+	"restore":       true,
+	"indexing":      true,
+	"schemacommit":  true,
+	"clustering":    true,
+}
+
+// IsCommitAction reports if the action writes data files, i.e. its completed instant holds commit metadata.
+func IsCommitAction(action string) bool {
+	return action == "commit" || action == "deltacommit" || action == "replacecommit"
+}
+
+// ParseInstant parses a timeline file name into an instant.
+//
+// Supported forms:
+//   - 20240101120000123.commit, 20240101120000123.commit.requested, 20240101120000123.inflight (timeline layout 1)
+//   - 20240101120000123_20240101120005456.commit, 20240101120000123.commit.inflight (timeline layout 2)
+//
+// Returns false if the file is not an instant.
+func ParseInstant(fileName string) (*formats.HudiInstant, bool) {
+
+	ts, rest, found := strings.Cut(fileName, ".")
+	if !found || rest == "" {
+		return nil, false
+	}
+
+	requested, completion, _ := strings.Cut(ts, "_")
+	if !isDigits(requested) || (completion != "" && !isDigits(completion)) {
+		return nil, false
+	}
+
+	instant := &formats.HudiInstant{
+		Timestamp:      requested,
+		CompletionTime: completion,
+		State:          StateCompleted,
+		FileName:       fileName,
+	}
+
+	// the legacy inflight commit has no action in its name.
+	if rest == StateInflight {
+		instant.Action = "commit"
+		instant.State = StateInflight
+		return instant, true
+	}
+
+	action, state, _ := strings.Cut(rest, ".")
+	if !actions[action] {
+		return nil, false
+	}
+	instant.Action = action
+
+	switch state {
+	case "":
+	case StateRequested, StateInflight:
+		instant.State = state
+	default:
+		return nil, false
+	}
+
+	return instant, true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package hudiutils
+
+import (
+	formats "lakelens/internal/dto/formats/hudi"
+	"testing"
+)
+
+func TestParseInstant(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		fileName string
+		want     *formats.HudiInstant
+	}{
+		{
+			name:     "completed commit",
+			fileName: "20240101120000123.commit",
+			want:     &formats.HudiInstant{Timestamp: "20240101120000123", Action: "commit", State: StateCompleted},
+		},
+		{
+			name:     "requested deltacommit",
+			fileName: "20240101120000123.deltacommit.requested",
+			want:     &formats.HudiInstant{Timestamp: "20240101120000123", Action: "deltacommit", State: StateRequested},
+		},
+		{
+			name:     "inflight replacecommit",
+			fileName: "20240101120000123.replacecommit.inflight",
+			want:     &formats.HudiInstant{Timestamp: "20240101120000123", Action: "replacecommit", State: StateInflight},
+		},
+		{
+			name:     "legacy inflight commit",
+			fileName: "20240101120000123.inflight",
+			want:     &formats.HudiInstant{Timestamp: "20240101120000123", Action: "commit", State: StateInflight},
+		},
+		{
+			name:     "layout 2 completed with completion time",
+			fileName: "20240101120000123_20240101120005456.clean",
+			want: &formats.HudiInstant{
+				Timestamp:      "20240101120000123",
+				CompletionTime: "20240101120005456",
+				Action:         "clean",
+				State:          StateCompleted,
+			},
+		},
+		{
+			name:     "unknown action",
+			fileName: "20240101120000123.unknown",
+		},
+		{
+			name:     "unknown state",
+			fileName: "20240101120000123.commit.done",
+		},
+		{
+			name:     "non numeric timestamp",
+			fileName: "hoodie.properties",
+		},
+		{
+			name:     "non numeric completion time",
+			fileName: "20240101120000123_abc.commit",
+		},
+		{
+			name:     "no extension",
+			fileName: "20240101120000123",
+		},
+		{
+			name:     "folder",
+			fileName: ".aux",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseInstant(tt.fileName)
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("ParseInstant(%q) = %+v, want not an instant", tt.fileName, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("ParseInstant(%q) is not an instant, want %+v", tt.fileName, tt.want)
+			}
+
+			tt.want.FileName = tt.fileName
+			if *got != *tt.want {
+				t.Errorf("ParseInstant(%q) = %+v, want %+v", tt.fileName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCommitAction(t *testing.T) {
+
+	tests := []struct {
+		action string
+		want   bool
+	}{
+		{"commit", true},
+		{"deltacommit", true},
+		{"replacecommit", true},
+		{"clean", false},
+		{"compaction", false},
+		{"rollback", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCommitAction(tt.action); got != tt.want {
+			t.Errorf("IsCommitAction(%q) = %v, want %v", tt.action, got, tt.want)
+		}
+	}
+}
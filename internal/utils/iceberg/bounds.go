@@ -0,0 +1,286 @@
+package iceutils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The layouts of the decoded temporal bounds, fixed width so they sort as their values.
+const (
+	dateLayout        = "2006-01-02"
+	timeLayout        = "15:04:05.000000"
+	timestampLayout   = "2006-01-02T15:04:05.000000"
+	timestampNsLayout = "2006-01-02T15:04:05.000000000"
+)
+
+// KeyValues returns the values of the given avro key/value array (like lower_bounds or column_sizes) by key.
+// The array is decoded as {"array": [{"key": .., "value": ..}]}, a null one is empty.
+func KeyValues(kvs map[string]any) map[int64]any {
+
+	values := make(map[int64]any)
+
+	arr, _ := kvs["array"].([]any)
+	for _, elem := range arr {
+		kv, ok := elem.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		key, err := strconv.ParseInt(fmt.Sprintf("%v", kv["key"]), 10, 64)
+		if err != nil {
+			continue
+		}
+		values[key] = kv["value"]
+	}
+
+	return values
+}
+
+// Bounds returns the binary bounds of the given lower_bounds or upper_bounds map, by field id.
+func Bounds(kvs map[string]any) map[int64][]byte {
+
+	bounds := make(map[int64][]byte)
+	for key, value := range KeyValues(kvs) {
+		switch raw := value.(type) {
+		case []byte:
+			bounds[key] = raw
+		case string:
+			bounds[key] = []byte(raw)
+		}
+	}
+
+	return bounds
+}
+
+// Counts returns the counts of the given count map (like value_counts or null_value_counts), by field id.
+func Counts(kvs map[string]any) map[int64]int64 {
+
+	counts := make(map[int64]int64)
+	for key, value := range KeyValues(kvs) {
+		count, err := strconv.ParseInt(fmt.Sprintf("%v", value), 10, 64)
+		if err == nil {
+			counts[key] = count
+		}
+	}
+
+	return counts
+}
+
+// DecodeBound deserializes the given single value (the binary form of the Iceberg spec) of a primitive type.
+//
+// Dates, times and timestamps are formatted (timestamps without a zone in UTC), uuids in their canonical form,
+// fixed and binary values in hex and decimals as their exact decimal string.
+func DecodeBound(typ string, raw []byte) (any, error) {
+
+	badLength := fmt.Errorf("invalid %d bytes value for the %s type", len(raw), typ)
+
+	switch {
+	case typ == "boolean":
+		if len(raw) != 1 {
+			return nil, badLength
+		}
+		return raw[0] != 0, nil
+
+	case typ == "int":
+		if len(raw) != 4 {
+			return nil, badLength
+		}
+		return int32(binary.LittleEndian.Uint32(raw)), nil
+
+	case typ == "long":
+		// the bounds written before an int to long promotion are 4 bytes.
+		switch len(raw) {
+		case 4:
+			return int64(int32(binary.LittleEndian.Uint32(raw))), nil
+		case 8:
+			return int64(binary.LittleEndian.Uint64(raw)), nil
+		}
+		return nil, badLength
+
+	case typ == "float":
+		if len(raw) != 4 {
+			return nil, badLength
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(raw)), nil
+
+	case typ == "double":
+		// the bounds written before a float to double promotion are 4 bytes.
+		switch len(raw) {
+		case 4:
+			return float64(math.Float32frombits(binary.LittleEndian.Uint32(raw))), nil
+		case 8:
+			return math.Float64frombits(binary.LittleEndian.Uint64(raw)), nil
+		}
+		return nil, badLength
+
+	case typ == "date":
+		if len(raw) != 4 {
+			return nil, badLength
+		}
+		days := int64(int32(binary.LittleEndian.Uint32(raw)))
+		return time.Unix(days*86400, 0).UTC().Format(dateLayout), nil
+
+	case typ == "time":
+		if len(raw) != 8 {
+			return nil, badLength
+		}
+		micros := int64(binary.LittleEndian.Uint64(raw))
+		return time.UnixMicro(micros).UTC().Format(timeLayout), nil
+
+	case typ == "timestamp" || typ == "timestamptz":
+		if len(raw) != 8 {
+			return nil, badLength
+		}
+		ts := time.UnixMicro(int64(binary.LittleEndian.Uint64(raw))).UTC().Format(timestampLayout)
+		if typ == "timestamptz" {
+			ts += "Z"
+		}
+		return ts, nil
+
+	case typ == "timestamp_ns" || typ == "timestamptz_ns":
+		if len(raw) != 8 {
+			return nil, badLength
+		}
+		ts := time.Unix(0, int64(binary.LittleEndian.Uint64(raw))).UTC().Format(timestampNsLayout)
+		if typ == "timestamptz_ns" {
+			ts += "Z"
+		}
+		return ts, nil
+
+	case typ == "string":
+		return string(raw), nil
+
+	case typ == "uuid":
+		if len(raw) != 16 {
+			return nil, badLength
+		}
+		return fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:16]), nil
+
+	case typ == "binary" || strings.HasPrefix(typ, "fixed"):
+		return hex.EncodeToString(raw), nil
+
+	case strings.HasPrefix(typ, "decimal"):
+		_, scale, ok := DecimalPrecisionScale(typ)
+		if !ok {
+			return nil, fmt.Errorf("invalid decimal type %s", typ)
+		}
+		return formatDecimal(decimalUnscaled(raw), scale), nil
+	}
+
+	return nil, fmt.Errorf("bounds of the %s type can not be decoded", typ)
+}
+
+// CompareBounds compares two binary bounds of the given primitive type by their values.
+func CompareBounds(typ string, a, b []byte) int {
+
+	switch {
+	case typ == "int" || typ == "long" || typ == "date" || typ == "time" || strings.HasPrefix(typ, "timestamp"):
+		x, errX := DecodeBound(typ, a)
+		y, errY := DecodeBound(typ, b)
+		if errX != nil || errY != nil {
+			return bytes.Compare(a, b)
+		}
+		// the temporal types are formatted with fixed widths.
+		switch x := x.(type) {
+		case int32:
+			return cmpOrdered(x, y.(int32))
+		case int64:
+			return cmpOrdered(x, y.(int64))
+		case string:
+			return strings.Compare(x, y.(string))
+		}
+
+	case typ == "float" || typ == "double":
+		x, errX := DecodeBound(typ, a)
+		y, errY := DecodeBound(typ, b)
+		if errX != nil || errY != nil {
+			return bytes.Compare(a, b)
+		}
+		switch x := x.(type) {
+		case float32:
+			return cmpOrdered(x, y.(float32))
+		case float64:
+			return cmpOrdered(x, y.(float64))
+		}
+
+	case typ == "boolean":
+		return bytes.Compare(a, b)
+
+	case strings.HasPrefix(typ, "decimal"):
+		return decimalUnscaled(a).Cmp(decimalUnscaled(b))
+	}
+
+	// strings (utf-8), uuids, fixed and binary values sort by their unsigned bytes.
+	return bytes.Compare(a, b)
+}
+
+func cmpOrdered[T int32 | int64 | float32 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// DecimalPrecisionScale parses the precision and scale of a decimal(P, S) type.
+func DecimalPrecisionScale(typ string) (int, int, bool) {
+
+	inner, ok := strings.CutPrefix(strings.ReplaceAll(typ, " ", ""), "decimal(")
+	if !ok {
+		return 0, 0, false
+	}
+	inner, ok = strings.CutSuffix(inner, ")")
+	if !ok {
+		return 0, 0, false
+	}
+
+	p, s, ok := strings.Cut(inner, ",")
+	if !ok {
+		return 0, 0, false
+	}
+	precision, err1 := strconv.Atoi(p)
+	scale, err2 := strconv.Atoi(s)
+	if err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+
+	return precision, scale, true
+}
+
+// decimalUnscaled returns the unscaled value of a decimal, big endian two's complement in the minimum bytes.
+func decimalUnscaled(raw []byte) *big.Int {
+
+	unscaled := new(big.Int).SetBytes(raw)
+	if len(raw) > 0 && raw[0]&0x80 != 0 {
+		unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(raw)*8)))
+	}
+
+	return unscaled
+}
+
+func formatDecimal(unscaled *big.Int, scale int) string {
+
+	digits := new(big.Int).Abs(unscaled).String()
+	sign := ""
+	if unscaled.Sign() < 0 {
+		sign = "-"
+	}
+
+	if scale <= 0 {
+		return sign + digits + strings.Repeat("0", -scale)
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
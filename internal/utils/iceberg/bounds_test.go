@@ -0,0 +1,135 @@
+package iceutils
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func le32(v int32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, uint32(v))
+}
+
+func le64(v int64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, uint64(v))
+}
+
+func TestDecodeBound(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		typ     string
+		raw     []byte
+		want    any
+		wantErr bool
+	}{
+		{name: "boolean true", typ: "boolean", raw: []byte{1}, want: true},
+		{name: "boolean false", typ: "boolean", raw: []byte{0}, want: false},
+		{name: "int", typ: "int", raw: le32(-42), want: int32(-42)},
+		{name: "long", typ: "long", raw: le64(math.MaxInt64), want: int64(math.MaxInt64)},
+		{name: "long promoted from int", typ: "long", raw: le32(-7), want: int64(-7)},
+		{name: "float", typ: "float", raw: binary.LittleEndian.AppendUint32(nil, math.Float32bits(1.5)), want: float32(1.5)},
+		{name: "double", typ: "double", raw: binary.LittleEndian.AppendUint64(nil, math.Float64bits(-2.25)), want: -2.25},
+		{name: "double promoted from float", typ: "double", raw: binary.LittleEndian.AppendUint32(nil, math.Float32bits(0.5)), want: 0.5},
+		{name: "date", typ: "date", raw: le32(19723), want: "2024-01-01"},
+		{name: "date before epoch", typ: "date", raw: le32(-1), want: "1969-12-31"},
+		{name: "time", typ: "time", raw: le64(3661000001), want: "01:01:01.000001"},
+		{name: "timestamp", typ: "timestamp", raw: le64(1704067200000000), want: "2024-01-01T00:00:00.000000"},
+		{name: "timestamptz", typ: "timestamptz", raw: le64(1704067200000001), want: "2024-01-01T00:00:00.000001Z"},
+		{name: "timestamp_ns", typ: "timestamp_ns", raw: le64(1704067200000000001), want: "2024-01-01T00:00:00.000000001"},
+		{name: "timestamptz_ns", typ: "timestamptz_ns", raw: le64(1704067200000000000), want: "2024-01-01T00:00:00.000000000Z"},
+		{name: "string", typ: "string", raw: []byte("héllo"), want: "héllo"},
+		{
+			name: "uuid",
+			typ:  "uuid",
+			raw:  []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			want: "00010203-0405-0607-0809-0a0b0c0d0e0f",
+		},
+		{name: "fixed", typ: "fixed[3]", raw: []byte{0xAB, 0x00, 0x01}, want: "ab0001"},
+		{name: "binary", typ: "binary", raw: []byte{0xFF}, want: "ff"},
+		{name: "decimal positive", typ: "decimal(10, 2)", raw: []byte{0x30, 0x39}, want: "123.45"},
+		{name: "decimal negative", typ: "decimal(10, 2)", raw: []byte{0xCF, 0xC7}, want: "-123.45"},
+		{name: "decimal negative below one", typ: "decimal(10,2)", raw: []byte{0xFF}, want: "-0.01"},
+		{name: "decimal positive with sign byte", typ: "decimal(10, 2)", raw: []byte{0x00, 0x80}, want: "1.28"},
+		{name: "decimal zero scale", typ: "decimal(5, 0)", raw: []byte{0x7B}, want: "123"},
+		{name: "invalid decimal type", typ: "decimal(10)", raw: []byte{0x01}, wantErr: true},
+		{name: "int bad length", typ: "int", raw: []byte{1, 2, 3}, wantErr: true},
+		{name: "long bad length", typ: "long", raw: []byte{1, 2}, wantErr: true},
+		{name: "uuid bad length", typ: "uuid", raw: []byte{1}, wantErr: true},
+		{name: "nested type", typ: "list", raw: []byte{1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeBound(tt.typ, tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeBound(%q, %x) error = %v, wantErr %v", tt.typ, tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DecodeBound(%q, %x) = %#v, want %#v", tt.typ, tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareBounds(t *testing.T) {
+
+	f32 := func(v float32) []byte { return binary.LittleEndian.AppendUint32(nil, math.Float32bits(v)) }
+	f64 := func(v float64) []byte { return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)) }
+
+	tests := []struct {
+		name string
+		typ  string
+		a, b []byte
+		want int
+	}{
+		{name: "int negative before positive", typ: "int", a: le32(-1), b: le32(1), want: -1},
+		{name: "int equal", typ: "int", a: le32(5), b: le32(5), want: 0},
+		{name: "long across the byte order", typ: "long", a: le64(256), b: le64(255), want: 1},
+		{name: "long promoted from int", typ: "long", a: le32(-3), b: le64(2), want: -1},
+		{name: "float negative before positive", typ: "float", a: f32(-0.5), b: f32(0.25), want: -1},
+		{name: "double", typ: "double", a: f64(10), b: f64(9.5), want: 1},
+		{name: "date before epoch", typ: "date", a: le32(-10), b: le32(0), want: -1},
+		{name: "time", typ: "time", a: le64(1), b: le64(0), want: 1},
+		{name: "timestamp", typ: "timestamptz", a: le64(1704067200000000), b: le64(1704067200000001), want: -1},
+		{name: "boolean", typ: "boolean", a: []byte{0}, b: []byte{1}, want: -1},
+		{name: "decimal negative before positive", typ: "decimal(10, 2)", a: []byte{0xFF}, b: []byte{0x01}, want: -1},
+		{name: "decimal different lengths", typ: "decimal(10, 2)", a: []byte{0x00, 0x80}, b: []byte{0x7F}, want: 1},
+		{name: "decimal negatives", typ: "decimal(10, 2)", a: []byte{0xCF, 0xC7}, b: []byte{0xFF}, want: -1},
+		{name: "string by bytes", typ: "string", a: []byte("apple"), b: []byte("banana"), want: -1},
+		{name: "binary unsigned", typ: "binary", a: []byte{0xFF}, b: []byte{0x01}, want: 1},
+		{name: "int bad length falls back to bytes", typ: "int", a: []byte{1}, b: []byte{2}, want: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareBounds(tt.typ, tt.a, tt.b); got != tt.want {
+				t.Errorf("CompareBounds(%q, %x, %x) = %d, want %d", tt.typ, tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalPrecisionScale(t *testing.T) {
+
+	tests := []struct {
+		typ       string
+		precision int
+		scale     int
+		ok        bool
+	}{
+		{typ: "decimal(10, 2)", precision: 10, scale: 2, ok: true},
+		{typ: "decimal(38,0)", precision: 38, scale: 0, ok: true},
+		{typ: "decimal(10)"},
+		{typ: "decimal(a, b)"},
+		{typ: "double"},
+	}
+
+	for _, tt := range tests {
+		precision, scale, ok := DecimalPrecisionScale(tt.typ)
+		if precision != tt.precision || scale != tt.scale || ok != tt.ok {
+			t.Errorf("DecimalPrecisionScale(%q) = (%d, %d, %v), want (%d, %d, %v)",
+				tt.typ, precision, scale, ok, tt.precision, tt.scale, tt.ok)
+		}
+	}
+}
@@ -16,10 +16,14 @@ func CleanManifestEntry(entriesMap []map[string]any) []formats.ManifestEntry {
 		if !ok {
 			continue
 		}
+		// the snapshot id is null for entries inheriting it from the manifest list.
+		snapshotID, _ := entryMap["snapshot_id"].(map[string]any)
+		// the equality ids are only set for the equality delete files.
+		equalityIDs, _ := dataFileMap["equality_ids"].(map[string]any)
 		manifestEntry := formats.ManifestEntry{
 			FileSequenceNumber: toNullableInt64(entryMap["file_sequence_number"]),
 			SequenceNumber:     toNullableInt64(entryMap["sequence_number"]),
-			SnapshotID:         entryMap["snapshot_id"].(map[string]any),
+			SnapshotID:         snapshotID,
 			Status:             int(entryMap["status"].(int32)),
 			DataFile: formats.ManifestDataFile{
 				FilePath:        dataFileMap["file_path"].(string),
@@ -34,6 +38,8 @@ func CleanManifestEntry(entriesMap []map[string]any) []formats.ManifestEntry {
 				ValueCounts:     dataFileMap["value_counts"].(map[string]any),
 				NullValueCounts: dataFileMap["null_value_counts"].(map[string]any),
 				NANValueCounts:  dataFileMap["nan_value_counts"].(map[string]any),
+				EqualityIDs:     equalityIDs,
+				SortOrderID:     toNullableInt32(unionValue(dataFileMap["sort_order_id"])),
 				// Add other fields like column stats, etc. if you want
 			},
 		}
@@ -119,3 +125,12 @@ func toNullableInt64(v any) *int64 {
 	num := v.(int64)
 	return &num
 }
+
+func toNullableInt32(v any) *int64 {
+	num, ok := v.(int32)
+	if !ok {
+		return nil
+	}
+	wide := int64(num)
+	return &wide
+}
@@ -0,0 +1,186 @@
+package iceutils
+
+import (
+	"fmt"
+	formats "lakelens/internal/dto/formats/iceberg"
+	"slices"
+	"strings"
+)
+
+// The status of a manifest entry.
+const (
+	StatusExisting = 0
+	StatusAdded    = 1
+	StatusDeleted  = 2
+)
+
+// The content of a manifest, in the manifest list.
+const (
+	ContentData    = 0
+	ContentDeletes = 1
+)
+
+// EntrySnapshotID returns the id of the snapshot that added or deleted the entry. Entries without one
+// inherit it from the manifest list record of their manifest.
+func EntrySnapshotID(entry *formats.ManifestEntry, record *formats.SnapshotRecord) int64 {
+
+	if id, ok := entry.SnapshotID["long"].(int64); ok {
+		return id
+	}
+
+	return record.AddedSnapshotID
+}
+
+// IsLive reports whether the entry is a live file of the snapshots listing its manifest.
+func IsLive(entry *formats.ManifestEntry) bool {
+	return entry.Status != StatusDeleted
+}
+
+// CountSnapshotFiles counts the data and delete files of the given snapshot, from its manifest list records
+// and the manifests read.
+func CountSnapshotFiles(files *formats.IcebergSnapshotFiles, manifests map[string]*formats.ManifestData) {
+
+	files.Data = formats.IcebergFileCounts{}
+	files.Deletes = formats.IcebergFileCounts{}
+
+	for _, record := range files.Manifests {
+		manifest, ok := manifests[record.ManifestPath]
+		if !ok {
+			continue
+		}
+
+		counts := &files.Data
+		if record.Content == ContentDeletes {
+			counts = &files.Deletes
+		}
+
+		for i := range manifest.Entries {
+			entry := &manifest.Entries[i]
+			ownEntry := EntrySnapshotID(entry, record) == files.SnapshotID
+
+			switch {
+			case entry.Status == StatusAdded && ownEntry:
+				counts.AddedFiles++
+				counts.AddedRecords += entry.DataFile.RecordCount
+				counts.AddedBytes += entry.DataFile.FileSizeInBytes
+			case entry.Status == StatusDeleted && ownEntry:
+				counts.DeletedFiles++
+				counts.DeletedRecords += entry.DataFile.RecordCount
+				counts.DeletedBytes += entry.DataFile.FileSizeInBytes
+			case IsLive(entry):
+				counts.ExistingFiles++
+				counts.ExistingRecords += entry.DataFile.RecordCount
+				counts.ExistingBytes += entry.DataFile.FileSizeInBytes
+			}
+		}
+	}
+}
+
+// The content of a data file, in its manifest entry.
+const (
+	FileContentData            = 0
+	FileContentPositionDeletes = 1
+	FileContentEqualityDeletes = 2
+)
+
+// FileContent returns the content of the entry file, data for the v1 manifests without one.
+func FileContent(entry *formats.ManifestEntry) int {
+
+	switch content := entry.DataFile.Content.(type) {
+	case int32:
+		return int(content)
+	case int64:
+		return int(content)
+	case int:
+		return content
+	}
+
+	return FileContentData
+}
+
+// FileContentName returns the name of the given file content.
+func FileContentName(content int) string {
+
+	switch content {
+	case FileContentPositionDeletes:
+		return "position_deletes"
+	case FileContentEqualityDeletes:
+		return "equality_deletes"
+	}
+
+	return "data"
+}
+
+// LiveFiles returns the live files of the given snapshot (data and deletes), by path.
+func LiveFiles(files *formats.IcebergSnapshotFiles, manifests map[string]*formats.ManifestData) map[string]*formats.ManifestEntry {
+
+	live := make(map[string]*formats.ManifestEntry)
+	for _, record := range files.Manifests {
+		manifest, ok := manifests[record.ManifestPath]
+		if !ok {
+			continue
+		}
+
+		for i := range manifest.Entries {
+			entry := &manifest.Entries[i]
+			if IsLive(entry) {
+				live[entry.DataFile.FilePath] = entry
+			}
+		}
+	}
+
+	return live
+}
+
+// PartitionKey returns the partition of the file as field=value pairs, like ts_day=19000/bucket=3 .
+func PartitionKey(partition map[string]any) string {
+
+	names := make([]string, 0, len(partition))
+	for name := range partition {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value := unionValue(partition[name])
+		if value == nil {
+			value = "null"
+		}
+		pairs = append(pairs, name+"="+fmt.Sprintf("%v", value))
+	}
+
+	return strings.Join(pairs, "/")
+}
+
+// unionValue returns the value of an avro union, decoded as a single entry map of its type, nil stays nil.
+func unionValue(value any) any {
+
+	if union, ok := value.(map[string]any); ok && len(union) == 1 {
+		for _, v := range union {
+			return v
+		}
+	}
+
+	return value
+}
+
+// IntArray returns the values of the given avro int (or long) array union, like equality_ids, null is empty.
+func IntArray(union map[string]any) []int64 {
+
+	arr, _ := union["array"].([]any)
+
+	values := make([]int64, 0, len(arr))
+	for _, elem := range arr {
+		switch value := elem.(type) {
+		case int32:
+			values = append(values, int64(value))
+		case int64:
+			values = append(values, value)
+		case int:
+			values = append(values, int64(value))
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,151 @@
+package iceutils
+
+import (
+	"encoding/binary"
+	"fmt"
+	formats "lakelens/internal/dto/formats/iceberg"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// The partition transforms, bucket and truncate are parameterized like bucket[16] and truncate[4].
+const (
+	TransformIdentity = "identity"
+	TransformBucket   = "bucket"
+	TransformTruncate = "truncate"
+	TransformYear     = "year"
+	TransformMonth    = "month"
+	TransformDay      = "day"
+	TransformHour     = "hour"
+	TransformVoid     = "void"
+)
+
+// TransformName returns the name of the given transform, without its parameter.
+func TransformName(transform string) string {
+
+	name, _, _ := strings.Cut(transform, "[")
+	return name
+}
+
+// PartitionValue renders the partition value of a field with the given transform, its source field being of the
+// given type. The temporal transforms are rendered as their period, like 2024, 2024-03, 2024-03-09 and 2024-03-09-10,
+// the identity and truncate ones as their source values, nulls as null.
+func PartitionValue(transform, sourceType string, value any) string {
+
+	value = unionValue(value)
+	if value == nil {
+		return "null"
+	}
+
+	switch TransformName(transform) {
+	case TransformVoid:
+		return "null"
+
+	case TransformYear, TransformMonth, TransformDay, TransformHour:
+		return periodValue(TransformName(transform), value)
+
+	case TransformIdentity, TransformTruncate:
+		return sourceValue(sourceType, value)
+	}
+
+	// the bucket numbers, and the unknown transforms.
+	return fmt.Sprintf("%v", value)
+}
+
+// periodValue renders the value of a temporal transform, the periods since the epoch (or a date for days).
+func periodValue(transform string, value any) string {
+
+	var n int64
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(dateLayout)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case int:
+		n = int64(v)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+
+	switch transform {
+	case TransformYear:
+		return fmt.Sprintf("%04d", 1970+n)
+	case TransformMonth:
+		// floored, the months before the epoch are negative.
+		year, month := 1970+floorDiv(n, 12), n-floorDiv(n, 12)*12
+		return fmt.Sprintf("%04d-%02d", year, month+1)
+	case TransformDay:
+		return time.Unix(n*86400, 0).UTC().Format(dateLayout)
+	}
+
+	return time.Unix(n*3600, 0).UTC().Format("2006-01-02-15")
+}
+
+func floorDiv(a, b int64) int64 {
+
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+
+	return q
+}
+
+// sourceValue renders a value of the given source type, decoded by avro as its primitive (or logical) type.
+func sourceValue(sourceType string, value any) string {
+
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return fmt.Sprintf("%v", v)
+	case []byte:
+		raw = v
+	case int32:
+		raw = binary.LittleEndian.AppendUint32(nil, uint32(v))
+	case int64:
+		raw = binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case time.Time:
+		switch sourceType {
+		case "date":
+			return v.UTC().Format(dateLayout)
+		case "timestamptz", "timestamptz_ns":
+			return v.UTC().Format(timestampLayout) + "Z"
+		}
+		return v.UTC().Format(timestampLayout)
+	case time.Duration:
+		return time.Unix(0, 0).Add(v).UTC().Format(timeLayout)
+	case *big.Rat:
+		_, scale, _ := DecimalPrecisionScale(sourceType)
+		return v.FloatString(scale)
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+
+	decoded, err := DecodeBound(sourceType, raw)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return fmt.Sprintf("%v", decoded)
+}
+
+// SourceTypes returns the primitive types of the fields of every schema by id, the later schemas winning, so the
+// partition fields whose source was dropped still get a type.
+func SourceTypes(schemas []formats.IcebergSchema) map[int64]string {
+
+	types := make(map[int64]string)
+	for _, schema := range schemas {
+		for _, field := range FlattenFields(schema.Fields) {
+			if field.Type.Nested == "" {
+				types[field.ID] = field.Type.Primitive
+			}
+		}
+	}
+
+	return types
+}
@@ -0,0 +1,103 @@
+package iceutils
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPartitionValue(t *testing.T) {
+
+	day := time.Date(2024, 3, 9, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		transform  string
+		sourceType string
+		value      any
+		want       string
+	}{
+		{name: "null", transform: "identity", sourceType: "long", value: nil, want: "null"},
+		{name: "null union", transform: "identity", sourceType: "long", value: map[string]any{"null": nil}, want: "null"},
+		{name: "void", transform: "void", sourceType: "string", value: "x", want: "null"},
+		{name: "identity long", transform: "identity", sourceType: "long", value: int64(5), want: "5"},
+		{name: "identity int union", transform: "identity", sourceType: "int", value: map[string]any{"int": int32(-3)}, want: "-3"},
+		{name: "identity string", transform: "identity", sourceType: "string", value: "abc", want: "abc"},
+		{name: "identity boolean", transform: "identity", sourceType: "boolean", value: true, want: "true"},
+		{name: "identity date", transform: "identity", sourceType: "date", value: day, want: "2024-03-09"},
+		{name: "identity timestamp", transform: "identity", sourceType: "timestamp", value: day, want: "2024-03-09T10:30:00.000000"},
+		{name: "identity timestamptz", transform: "identity", sourceType: "timestamptz", value: day, want: "2024-03-09T10:30:00.000000Z"},
+		{name: "identity time", transform: "identity", sourceType: "time", value: 3661000001 * time.Microsecond, want: "01:01:01.000001"},
+		{name: "identity decimal", transform: "identity", sourceType: "decimal(10, 2)", value: big.NewRat(12345, 100), want: "123.45"},
+		{
+			name:       "identity uuid",
+			transform:  "identity",
+			sourceType: "uuid",
+			value:      []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+			want:       "00010203-0405-0607-0809-0a0b0c0d0e0f",
+		},
+		{name: "truncate string", transform: "truncate[4]", sourceType: "string", value: "abcd", want: "abcd"},
+		{name: "truncate int", transform: "truncate[10]", sourceType: "int", value: int32(-10), want: "-10"},
+		{name: "bucket", transform: "bucket[16]", sourceType: "string", value: int32(3), want: "3"},
+		{name: "year", transform: "year", sourceType: "timestamp", value: int32(54), want: "2024"},
+		{name: "year before epoch", transform: "year", sourceType: "date", value: int32(-1), want: "1969"},
+		{name: "month", transform: "month", sourceType: "timestamp", value: int32(650), want: "2024-03"},
+		{name: "month before epoch", transform: "month", sourceType: "date", value: int32(-1), want: "1969-12"},
+		{name: "month before epoch across years", transform: "month", sourceType: "date", value: int32(-13), want: "1968-12"},
+		{name: "day", transform: "day", sourceType: "timestamp", value: int32(19791), want: "2024-03-09"},
+		{name: "day as a date", transform: "day", sourceType: "timestamp", value: day, want: "2024-03-09"},
+		{name: "day before epoch", transform: "day", sourceType: "date", value: int32(-1), want: "1969-12-31"},
+		{name: "hour", transform: "hour", sourceType: "timestamptz", value: int32(19791*24 + 10), want: "2024-03-09-10"},
+		{name: "hour before epoch", transform: "hour", sourceType: "timestamp", value: int64(-1), want: "1969-12-31-23"},
+		{name: "unknown transform", transform: "zorder", sourceType: "long", value: int64(9), want: "9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PartitionValue(tt.transform, tt.sourceType, tt.value); got != tt.want {
+				t.Errorf("PartitionValue(%q, %q, %v) = %q, want %q", tt.transform, tt.sourceType, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransformName(t *testing.T) {
+
+	tests := []struct {
+		transform string
+		want      string
+	}{
+		{"identity", TransformIdentity},
+		{"bucket[16]", TransformBucket},
+		{"truncate[4]", TransformTruncate},
+		{"day", TransformDay},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := TransformName(tt.transform); got != tt.want {
+			t.Errorf("TransformName(%q) = %q, want %q", tt.transform, got, tt.want)
+		}
+	}
+}
+
+func TestFloorDiv(t *testing.T) {
+
+	tests := []struct {
+		a, b int64
+		want int64
+	}{
+		{7, 12, 0},
+		{12, 12, 1},
+		{-1, 12, -1},
+		{-12, 12, -1},
+		{-13, 12, -2},
+		{0, 12, 0},
+	}
+
+	for _, tt := range tests {
+		if got := floorDiv(tt.a, tt.b); got != tt.want {
+			t.Errorf("floorDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
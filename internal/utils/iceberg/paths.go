@@ -0,0 +1,104 @@
+package iceutils
+
+import (
+	"cmp"
+	"strconv"
+	"strings"
+)
+
+// The folders of a table under its location, the files of the default layout are under one of them.
+const (
+	metadataFolder = "metadata/"
+	dataFolder     = "data/"
+)
+
+// NormalizeURI returns the given file uri without its scheme, so the same object written through different
+// file systems (s3, s3a or s3n, file or none) compares equal. Like bucket/key, container/key or /abs/dir/key.
+func NormalizeURI(uri string) string {
+
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		// plain paths and the file:/abs/dir/key form of hadoop.
+		return strings.TrimPrefix(uri, "file:")
+	}
+
+	switch strings.ToLower(scheme) {
+	case "abfs", "abfss", "wasb", "wasbs":
+		// container@account.dfs.core.windows.net/key, the same container is reachable by both endpoints.
+		host, key, _ := strings.Cut(rest, "/")
+		container, _, _ := strings.Cut(host, "@")
+		return container + "/" + key
+	}
+
+	return rest
+}
+
+// RelativePath returns the path of the given file uri relative to the table location (the location field of
+// its metadata), false if the file is not under it.
+//
+// A table copied to another bucket or prefix keeps the location of the original in its metadata, so if the
+// buckets differ the paths are matched on their keys, and if the prefixes differ too on the metadata/ or data/
+// folder of the table, the file relative to the last of them.
+func RelativePath(location, uri string) (string, bool) {
+
+	loc := strings.TrimSuffix(NormalizeURI(location), "/")
+	uriPath := NormalizeURI(uri)
+
+	if rel, ok := strings.CutPrefix(uriPath, loc+"/"); ok && rel != "" {
+		return rel, true
+	}
+
+	_, locKey, _ := strings.Cut(loc, "/")
+	_, uriKey, _ := strings.Cut(uriPath, "/")
+	if locKey != "" {
+		if rel, ok := strings.CutPrefix(uriKey, locKey+"/"); ok && rel != "" {
+			return rel, true
+		}
+	}
+
+	key := "/" + uriKey
+	anchor := max(strings.LastIndex(key, "/"+metadataFolder), strings.LastIndex(key, "/"+dataFolder))
+	if anchor < 0 || strings.HasSuffix(key, "/") {
+		return "", false
+	}
+
+	return key[anchor+1:], true
+}
+
+// MetadataVersion parses the version of a metadata file from its name, the v12.metadata.json of the hadoop
+// catalog or the 00012-<uuid>.metadata.json of the others. False if the name has no version.
+func MetadataVersion(key string) (int64, bool) {
+
+	name := key[strings.LastIndex(key, "/")+1:]
+	name = strings.TrimPrefix(name, "v")
+
+	end := strings.IndexFunc(name, func(r rune) bool { return r < '0' || r > '9' })
+	if end <= 0 {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(name[:end], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// CompareMetadataFiles orders metadata files by their versions, the files without one first. Same versions are
+// ordered by their names.
+func CompareMetadataFiles(a, b string) int {
+
+	va, okA := MetadataVersion(a)
+	vb, okB := MetadataVersion(b)
+
+	switch {
+	case okA != okB && okA:
+		return 1
+	case okA != okB:
+		return -1
+	case va != vb:
+		return cmp.Compare(va, vb)
+	}
+
+	return strings.Compare(a, b)
+}
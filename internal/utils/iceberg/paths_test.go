@@ -0,0 +1,173 @@
+package iceutils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeURI(t *testing.T) {
+
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{"s3://bkt/wh/t/data/a.parquet", "bkt/wh/t/data/a.parquet"},
+		{"s3a://bkt/wh/t/data/a.parquet", "bkt/wh/t/data/a.parquet"},
+		{"S3N://bkt/wh/t", "bkt/wh/t"},
+		{"gs://bkt/t", "bkt/t"},
+		{"abfss://ctr@acct.dfs.core.windows.net/t/data/a.parquet", "ctr/t/data/a.parquet"},
+		{"wasbs://ctr@acct.blob.core.windows.net/t", "ctr/t"},
+		{"file:///tmp/wh/t", "/tmp/wh/t"},
+		{"file:/tmp/wh/t", "/tmp/wh/t"},
+		{"/tmp/wh/t", "/tmp/wh/t"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeURI(tt.uri); got != tt.want {
+			t.Errorf("NormalizeURI(%q) = %q, want %q", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestRelativePath(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		location string
+		uri      string
+		want     string
+		ok       bool
+	}{
+		{
+			name:     "under the location",
+			location: "s3://bkt/wh/db/t",
+			uri:      "s3://bkt/wh/db/t/data/a.parquet",
+			want:     "data/a.parquet", ok: true,
+		},
+		{
+			name:     "location with a trailing slash",
+			location: "s3://bkt/wh/db/t/",
+			uri:      "s3://bkt/wh/db/t/metadata/snap-1.avro",
+			want:     "metadata/snap-1.avro", ok: true,
+		},
+		{
+			name:     "s3a location and s3 files",
+			location: "s3a://bkt/wh/db/t",
+			uri:      "s3://bkt/wh/db/t/data/ts_day=2024-03-09/a.parquet",
+			want:     "data/ts_day=2024-03-09/a.parquet", ok: true,
+		},
+		{
+			name:     "copied to another bucket",
+			location: "s3://old/wh/db/t",
+			uri:      "s3://new/wh/db/t/metadata/v3.metadata.json",
+			want:     "metadata/v3.metadata.json", ok: true,
+		},
+		{
+			name:     "copied to another prefix",
+			location: "s3://old/warehouse/db/t",
+			uri:      "s3://new/backup/db/t/data/a.parquet",
+			want:     "data/a.parquet", ok: true,
+		},
+		{
+			name:     "copied to a prefix named data",
+			location: "s3://old/warehouse/db/t",
+			uri:      "s3://new/data/db/t/metadata/snap-1.avro",
+			want:     "metadata/snap-1.avro", ok: true,
+		},
+		{
+			name:     "table at the bucket root",
+			location: "s3://old",
+			uri:      "s3://new/data/a.parquet",
+			want:     "data/a.parquet", ok: true,
+		},
+		{
+			name:     "local table",
+			location: "file:///tmp/wh/t",
+			uri:      "/tmp/wh/t/data/a.parquet",
+			want:     "data/a.parquet", ok: true,
+		},
+		{
+			name:     "out of the table folders",
+			location: "s3://bkt/wh/db/t",
+			uri:      "s3://bkt/elsewhere/a.parquet",
+		},
+		{
+			name:     "the location itself",
+			location: "s3://bkt/wh/db/t",
+			uri:      "s3://bkt/wh/db/t/",
+		},
+		{
+			name:     "a table folder",
+			location: "s3://bkt/wh/db/t",
+			uri:      "s3://other/x/data/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RelativePath(tt.location, tt.uri)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("RelativePath(%q, %q) = (%q, %v), want (%q, %v)", tt.location, tt.uri, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMetadataVersion(t *testing.T) {
+
+	tests := []struct {
+		key     string
+		version int64
+		ok      bool
+	}{
+		{key: "t/metadata/v12.metadata.json", version: 12, ok: true},
+		{key: "t/metadata/00012-1f2e3d4c-aaaa-bbbb-cccc-123456789abc.metadata.json", version: 12, ok: true},
+		{key: "00000-1f2e.metadata.json", version: 0, ok: true},
+		{key: "t/metadata/v12.gz.metadata.json", version: 12, ok: true},
+		{key: "t/metadata/metadata.json"},
+		{key: "t/metadata/version-hint.text"},
+		{key: "t/metadata/v.metadata.json"},
+	}
+
+	for _, tt := range tests {
+		version, ok := MetadataVersion(tt.key)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("MetadataVersion(%q) = (%d, %v), want (%d, %v)", tt.key, version, ok, tt.version, tt.ok)
+		}
+	}
+}
+
+func TestCompareMetadataFiles(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "numeric not lexical",
+			files: []string{"v10.metadata.json", "v9.metadata.json", "v2.metadata.json"},
+			want:  []string{"v2.metadata.json", "v9.metadata.json", "v10.metadata.json"},
+		},
+		{
+			name:  "uuid names",
+			files: []string{"00012-b.metadata.json", "00002-c.metadata.json", "00012-a.metadata.json"},
+			want:  []string{"00002-c.metadata.json", "00012-a.metadata.json", "00012-b.metadata.json"},
+		},
+		{
+			name:  "unversioned first",
+			files: []string{"v1.metadata.json", "metadata.json", "00000-a.metadata.json"},
+			want:  []string{"metadata.json", "00000-a.metadata.json", "v1.metadata.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(tt.files)
+			slices.SortFunc(got, CompareMetadataFiles)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sorted %q = %q, want %q", tt.files, got, tt.want)
+			}
+		})
+	}
+}
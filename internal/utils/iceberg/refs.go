@@ -0,0 +1,96 @@
+package iceutils
+
+import (
+	formats "lakelens/internal/dto/formats/iceberg"
+	"strconv"
+)
+
+// The ref types.
+const (
+	RefBranch = "branch"
+	RefTag    = "tag"
+
+	MainBranch = "main"
+)
+
+// The retention defaults of the spec, when neither the ref nor the table properties set one.
+const (
+	DefaultMaxSnapshotAgeMS   = 5 * 24 * 60 * 60 * 1000
+	DefaultMinSnapshotsToKeep = 1
+)
+
+// Retention is the snapshot retention of a ref, a zero max age never expires.
+type Retention struct {
+	MaxRefAgeMS        int64
+	MinSnapshotsToKeep int64
+	MaxSnapshotAgeMS   int64
+}
+
+// TableRefs returns the refs of the table. The tables without refs (before v2) get a main branch at the
+// current snapshot, if any.
+func TableRefs(metadata *formats.IcebergMetadata) formats.IcebergRefs {
+
+	refs := make(formats.IcebergRefs, len(metadata.Refs)+1)
+	for name, ref := range metadata.Refs {
+		refs[name] = ref
+	}
+
+	if _, ok := refs[MainBranch]; !ok && metadata.CurrentSnapshotID > 0 {
+		refs[MainBranch] = formats.IcebergRef{
+			SnapshotID: metadata.CurrentSnapshotID,
+			Type:       RefBranch,
+		}
+	}
+
+	return refs
+}
+
+// RefRetention returns the retention of the given ref, falling back to the table properties and then to the
+// defaults. The main branch never expires and tags keep no snapshots but their own.
+func RefRetention(name string, ref formats.IcebergRef, props formats.IcebergProperties) Retention {
+
+	retention := Retention{}
+
+	if name != MainBranch {
+		retention.MaxRefAgeMS = pick(ref.MaxRefAgeMS, props.History_Expire_MaxRefAgeMS, 0)
+	}
+	if ref.Type == RefBranch {
+		retention.MinSnapshotsToKeep = pick(ref.MinSnapshotsToKeep, props.History_Expire_MinSnapshotsToKeep, DefaultMinSnapshotsToKeep)
+		retention.MaxSnapshotAgeMS = pick(ref.MaxSnapshotAgeMS, props.History_Expire_MaxSnapshotAgeMS, DefaultMaxSnapshotAgeMS)
+	}
+
+	return retention
+}
+
+func pick(own *int64, prop string, def int64) int64 {
+
+	if own != nil {
+		return *own
+	}
+	if value, err := strconv.ParseInt(prop, 10, 64); err == nil {
+		return value
+	}
+
+	return def
+}
+
+// Ancestry returns the ids of the given snapshot and its ancestors still in the metadata, newest first.
+func Ancestry(metadata *formats.IcebergMetadata, snapID int64) []int64 {
+
+	parents := make(map[int64]int64, len(metadata.Snapshots))
+	for _, snap := range metadata.Snapshots {
+		parents[snap.SnapshotID] = snap.ParentSnapshotID
+	}
+
+	ancestry := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for id := snapID; !seen[id]; id = parents[id] {
+		if _, ok := parents[id]; !ok {
+			break
+		}
+		seen[id] = true
+		ancestry = append(ancestry, id)
+	}
+
+	return ancestry
+}
@@ -0,0 +1,115 @@
+package iceutils
+
+import (
+	formats "lakelens/internal/dto/formats/iceberg"
+)
+
+// FlatField is a schema field (or a list element, map key or map value) with its dotted path from the schema root.
+type FlatField struct {
+	ID       int64
+	Name     string // the last part of the path.
+	Path     string
+	ParentID int64 // 0 for the top level fields.
+	Required bool
+	Type     *formats.IcebergType
+
+	Doc            string
+	InitialDefault any
+	WriteDefault   any
+}
+
+// FlattenFields returns the given fields and all their nested fields, depth first. The list elements are
+// named element, the map keys and values key and value, like a.element.b or m.value .
+func FlattenFields(fields []formats.IcebergSchemaField) []*FlatField {
+
+	flat := make([]*FlatField, 0, len(fields))
+	for i := range fields {
+		flat = flattenField(flat, &fields[i], "", 0)
+	}
+
+	return flat
+}
+
+func flattenField(flat []*FlatField, field *formats.IcebergSchemaField, prefix string, parentID int64) []*FlatField {
+
+	path := prefix + field.Name
+	flat = append(flat, &FlatField{
+		ID:             field.ID,
+		Name:           field.Name,
+		Path:           path,
+		ParentID:       parentID,
+		Required:       field.Required,
+		Type:           &field.Type,
+		Doc:            field.Doc,
+		InitialDefault: field.InitialDefault,
+		WriteDefault:   field.WriteDefault,
+	})
+
+	return flattenType(flat, &field.Type, path+".", field.ID)
+}
+
+func flattenType(flat []*FlatField, typ *formats.IcebergType, prefix string, parentID int64) []*FlatField {
+
+	if typ == nil {
+		return flat
+	}
+
+	switch typ.Nested {
+	case formats.TypeStruct:
+		for i := range typ.Fields {
+			flat = flattenField(flat, &typ.Fields[i], prefix, parentID)
+		}
+	case formats.TypeList:
+		flat = flattenField(flat, &formats.IcebergSchemaField{
+			ID:       typ.ElementID,
+			Name:     "element",
+			Required: typ.ElementRequired,
+			Type:     derefType(typ.Element),
+		}, prefix, parentID)
+	case formats.TypeMap:
+		flat = flattenField(flat, &formats.IcebergSchemaField{
+			ID:       typ.KeyID,
+			Name:     "key",
+			Required: true,
+			Type:     derefType(typ.Key),
+		}, prefix, parentID)
+		flat = flattenField(flat, &formats.IcebergSchemaField{
+			ID:       typ.ValueID,
+			Name:     "value",
+			Required: typ.ValueRequired,
+			Type:     derefType(typ.Value),
+		}, prefix, parentID)
+	}
+
+	return flat
+}
+
+func derefType(typ *formats.IcebergType) formats.IcebergType {
+
+	if typ == nil {
+		return formats.IcebergType{}
+	}
+
+	return *typ
+}
+
+// PromotionAllowed reports whether the iceberg spec allows a field of the given primitive type to be promoted to the
+// other, int to long, float to double, a decimal to a wider precision of the same scale and a date to a timestamp.
+func PromotionAllowed(from, to string) bool {
+
+	switch {
+	case from == to:
+		return true
+	case from == "int" && to == "long":
+		return true
+	case from == "float" && to == "double":
+		return true
+	case from == "date" && (to == "timestamp" || to == "timestamp_ns"):
+		return true
+	}
+
+	fromP, fromS, ok1 := DecimalPrecisionScale(from)
+	toP, toS, ok2 := DecimalPrecisionScale(to)
+
+	return ok1 && ok2 && fromS == toS && toP >= fromP
+}